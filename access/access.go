@@ -0,0 +1,108 @@
+package access
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Role adalah peran pengguna yang menentukan aksi yang boleh dilakukan
+type Role string
+
+const (
+	// ROLE_CANDIDATE adalah peserta interview, hanya bisa mengakses chat miliknya sendiri
+	ROLE_CANDIDATE Role = "candidate"
+	// ROLE_REVIEWER adalah penilai yang membaca transcript dan nilai semua chat lalu memberikan review
+	ROLE_REVIEWER Role = "reviewer"
+	// ROLE_ADMIN mengelola akun, template, review, dan semua chat, tetapi tidak mengikuti interview
+	// atau membagikan transcript karena keduanya hanya dilakukan pemilik sesi interview
+	ROLE_ADMIN Role = "admin"
+	// ROLE_SERVICE adalah integrasi sistem lain yang membaca data chat dan statistik
+	ROLE_SERVICE Role = "service"
+)
+
+// Permission adalah aksi yang bisa diberikan ke peran
+type Permission string
+
+const (
+	PERMISSION_TAKE_INTERVIEW   Permission = "interview:take"
+	PERMISSION_READ_TRANSCRIPT  Permission = "transcript:read"
+	PERMISSION_SHARE_TRANSCRIPT Permission = "transcript:share"
+	PERMISSION_READ_SCORE       Permission = "score:read"
+	PERMISSION_LIST_CHATS       Permission = "chat:list"
+	PERMISSION_DELETE_CHAT      Permission = "chat:delete"
+	PERMISSION_READ_STATS       Permission = "stats:read"
+	PERMISSION_READ_REVIEW      Permission = "review:read"
+	PERMISSION_WRITE_REVIEW     Permission = "review:write"
+	PERMISSION_MANAGE_TEMPLATES Permission = "template:manage"
+	PERMISSION_MANAGE_ACCOUNTS  Permission = "account:manage"
+)
+
+// permissions berisi aksi yang boleh dilakukan setiap peran, izin kandidat dicek di rute /chat
+// untuk chat milik sesi interview itu sendiri
+var permissions = map[Role][]Permission{
+	ROLE_CANDIDATE: {
+		PERMISSION_TAKE_INTERVIEW,
+		PERMISSION_READ_TRANSCRIPT,
+		PERMISSION_SHARE_TRANSCRIPT,
+		PERMISSION_READ_SCORE,
+		PERMISSION_DELETE_CHAT,
+	},
+	ROLE_REVIEWER: {
+		PERMISSION_READ_TRANSCRIPT,
+		PERMISSION_READ_SCORE,
+		PERMISSION_LIST_CHATS,
+		PERMISSION_READ_REVIEW,
+		PERMISSION_WRITE_REVIEW,
+	},
+	ROLE_SERVICE: {
+		PERMISSION_READ_TRANSCRIPT,
+		PERMISSION_READ_SCORE,
+		PERMISSION_LIST_CHATS,
+		PERMISSION_READ_STATS,
+		PERMISSION_READ_REVIEW,
+	},
+	ROLE_ADMIN: {
+		PERMISSION_READ_TRANSCRIPT,
+		PERMISSION_READ_SCORE,
+		PERMISSION_LIST_CHATS,
+		PERMISSION_DELETE_CHAT,
+		PERMISSION_READ_STATS,
+		PERMISSION_READ_REVIEW,
+		PERMISSION_WRITE_REVIEW,
+		PERMISSION_MANAGE_TEMPLATES,
+		PERMISSION_MANAGE_ACCOUNTS,
+	},
+}
+
+// ParseRole digunakan untuk membaca peran dari string
+func ParseRole(s string) (Role, error) {
+	role := Role(s)
+	if _, ok := permissions[role]; !ok {
+		return "", fmt.Errorf("unknown role %q", s)
+	}
+
+	return role, nil
+}
+
+// Can digunakan untuk mengecek apakah peran boleh melakukan aksi
+func (r Role) Can(permission Permission) bool {
+	return slices.Contains(permissions[r], permission)
+}
+
+// Permissions digunakan untuk mendapatkan semua aksi yang boleh dilakukan peran
+func (r Role) Permissions() []Permission {
+	return slices.Clone(permissions[r])
+}
+
+// Principal adalah pengguna yang sedang mengakses API
+type Principal struct {
+	// ID adalah ID chat untuk kandidat atau ID akun untuk peran lain
+	ID   string
+	Name string
+	Role Role
+}
+
+// Can digunakan untuk mengecek apakah pengguna boleh melakukan aksi
+func (p Principal) Can(permission Permission) bool {
+	return p.Role.Can(permission)
+}
@@ -0,0 +1,78 @@
+package access
+
+import "testing"
+
+func TestParseRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		want    Role
+		wantErr bool
+	}{
+		{name: "candidate", role: "candidate", want: ROLE_CANDIDATE},
+		{name: "reviewer", role: "reviewer", want: ROLE_REVIEWER},
+		{name: "admin", role: "admin", want: ROLE_ADMIN},
+		{name: "service", role: "service", want: ROLE_SERVICE},
+		{name: "unknown role", role: "owner", wantErr: true},
+		{name: "empty role", role: "", wantErr: true},
+		{name: "role is case sensitive", role: "Admin", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRole(tt.role)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseRole() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoleCan(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       Role
+		permission Permission
+		want       bool
+	}{
+		{name: "candidate takes an interview", role: ROLE_CANDIDATE, permission: PERMISSION_TAKE_INTERVIEW, want: true},
+		{name: "candidate shares a transcript", role: ROLE_CANDIDATE, permission: PERMISSION_SHARE_TRANSCRIPT, want: true},
+		{name: "candidate deletes a chat", role: ROLE_CANDIDATE, permission: PERMISSION_DELETE_CHAT, want: true},
+		{name: "candidate cannot list chats", role: ROLE_CANDIDATE, permission: PERMISSION_LIST_CHATS},
+		{name: "candidate cannot read reviews", role: ROLE_CANDIDATE, permission: PERMISSION_READ_REVIEW},
+		{name: "reviewer writes a review", role: ROLE_REVIEWER, permission: PERMISSION_WRITE_REVIEW, want: true},
+		{name: "reviewer cannot delete a chat", role: ROLE_REVIEWER, permission: PERMISSION_DELETE_CHAT},
+		{name: "service reads stats", role: ROLE_SERVICE, permission: PERMISSION_READ_STATS, want: true},
+		{name: "service cannot write a review", role: ROLE_SERVICE, permission: PERMISSION_WRITE_REVIEW},
+		{name: "admin manages accounts", role: ROLE_ADMIN, permission: PERMISSION_MANAGE_ACCOUNTS, want: true},
+		{name: "admin cannot take an interview", role: ROLE_ADMIN, permission: PERMISSION_TAKE_INTERVIEW},
+		{name: "admin cannot share a transcript", role: ROLE_ADMIN, permission: PERMISSION_SHARE_TRANSCRIPT},
+		{name: "unknown role", role: "owner", permission: PERMISSION_READ_TRANSCRIPT},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.role.Can(tt.permission); got != tt.want {
+				t.Errorf("Can(%s) = %v, want %v", tt.permission, got, tt.want)
+			}
+
+			principal := Principal{ID: "id", Role: tt.role}
+			if got := principal.Can(tt.permission); got != tt.want {
+				t.Errorf("Principal.Can(%s) = %v, want %v", tt.permission, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRolePermissionsIsACopy(t *testing.T) {
+	permissions := ROLE_CANDIDATE.Permissions()
+	permissions[0] = PERMISSION_MANAGE_ACCOUNTS
+
+	if ROLE_CANDIDATE.Can(PERMISSION_MANAGE_ACCOUNTS) {
+		t.Error("changing Permissions() result changed the candidate role")
+	}
+}
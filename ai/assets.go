@@ -1,93 +1,88 @@
 package ai
 
 import (
-	"encoding/base64"
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 )
 
 type ChatAsset struct {
+	Language     Language
 	SystemPrompt string
 	ChatText     string
-	ChatAudio    string
 }
 
-func GetChatAsset() (ChatAsset, error) {
-	prompt, err := getSystemPrompt()
-	if err != nil {
-		return ChatAsset{}, err
+// GetChatAsset digunakan untuk mengambil aset awal chat sesuai bahasa
+func GetChatAsset(lang Language) (ChatAsset, error) {
+	// bahasa otomatis menggunakan aset bahasa default untuk pembuka
+	assetLang := lang
+	if assetLang.IsAuto() {
+		assetLang = DefaultLanguage
 	}
 
-	text, err := getInitialText()
+	prompt, err := getSystemPrompt(lang)
 	if err != nil {
 		return ChatAsset{}, err
 	}
 
-	audio, err := getInitialAudio()
+	text, err := getInitialText(assetLang)
 	if err != nil {
 		return ChatAsset{}, err
 	}
 
 	return ChatAsset{
+		Language:     lang,
 		SystemPrompt: prompt,
 		ChatText:     text,
-		ChatAudio:    audio,
 	}, nil
 }
 
-func getInitialAudio() (string, error) {
-	basedir, err := os.Getwd()
-	if err != nil {
-		log.Printf("error: %v\n", err)
-		return "", err
-	}
-
-	assetPath := filepath.Join(basedir, "ai", "assets", "initial.mp3")
-
-	audio, err := os.ReadFile(assetPath)
-	if err != nil {
-		log.Printf("error: %v\n", err)
-		return "", err
+// LanguageInstruction digunakan untuk membuat instruksi bahasa bagi AI
+func LanguageInstruction(lang Language) string {
+	if lang.IsAuto() {
+		return "You must conduct this interview in the same language the interviewee uses."
 	}
 
-	encoded := base64.StdEncoding.EncodeToString(audio)
-
-	return encoded, nil
+	return fmt.Sprintf("You must conduct this interview in %s.", lang.Name())
 }
 
-func getInitialText() (string, error) {
-	basedir, err := os.Getwd()
+func getInitialText(lang Language) (string, error) {
+	text, err := readAsset(string(lang), "initial.txt")
 	if err != nil {
-		log.Printf("error: %v\n", err)
 		return "", err
 	}
 
-	assetPath := filepath.Join(basedir, "ai", "assets", "initial.txt")
+	return string(text), nil
+}
 
-	text, err := os.ReadFile(assetPath)
+func getSystemPrompt(lang Language) (string, error) {
+	text, err := readAsset("system.txt")
 	if err != nil {
-		log.Printf("error: %v\n", err)
 		return "", err
 	}
 
-	return string(text), nil
+	return fmt.Sprintf("%s %s", text, LanguageInstruction(lang)), nil
 }
 
-func getSystemPrompt() (string, error) {
+// readAsset digunakan untuk membaca file dari direktori aset
+func readAsset(elem ...string) ([]byte, error) {
 	basedir, err := os.Getwd()
 	if err != nil {
 		log.Printf("error: %v\n", err)
-		return "", err
+		return nil, err
 	}
 
-	assetPath := filepath.Join(basedir, "ai", "assets", "system.txt")
+	assetPath := filepath.Join(append([]string{basedir, "ai", "assets"}, elem...)...)
 
-	text, err := os.ReadFile(assetPath)
+	content, err := os.ReadFile(assetPath)
 	if err != nil {
-		log.Printf("error: %v\n", err)
-		return "", err
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("error: %v\n", err)
+		}
+		return nil, err
 	}
 
-	return string(text), nil
+	return content, nil
 }
@@ -13,52 +13,77 @@ import (
 
 type Client interface {
 	Chat([]ChatMessage) (ChatResponse, error)
-	TextToSpeech(string) (io.ReadCloser, error)
-	Transcribe(io.ReadCloser, string) (TranscriptResponse, error)
+	StructuredChat([]ChatMessage, JSONSchema) (ChatResponse, error)
+	TextToSpeech(input, voice string) (io.ReadCloser, error)
+	Transcribe(io.ReadCloser, string, Language) (TranscriptResponse, error)
+	Moderate(string) (ModerationResponse, error)
 }
 
 type OpenAI struct {
-	APIKey             string
-	BaseURL            string
-	ChatModel          string
-	TranscriptModel    string
-	TranscriptLanguage string
-	TTSModel           string
-	TTSVoice           string
+	APIKey          string
+	BaseURL         string
+	ChatModel       string
+	TranscriptModel string
+	TTSModel        string
+	TTSVoice        string
+	ModerationModel string
 }
 
 const (
-	baseURL            = "https://api.openai.com/v1"
-	chatModel          = "gpt-4o"
-	transcriptModel    = "whisper-1"
-	transcriptLanguage = "en"
-	ttsModel           = "tts-1"
-	ttsVoice           = "nova"
+	baseURL         = "https://api.openai.com/v1"
+	chatModel       = "gpt-4o"
+	transcriptModel = "whisper-1"
+	ttsModel        = "tts-1"
+	ttsVoice        = "nova"
+	moderationModel = "omni-moderation-latest"
+
+	// format respons transkripsi yang menyertakan bahasa hasil deteksi serta waktu setiap kata dan segmen
+	transcriptFormatVerbose = "verbose_json"
 )
 
 // NewOpenAI digunakan untuk membuat instance client OpenAI
 func NewOpenAI(apiKey string) *OpenAI {
 	return &OpenAI{
-		APIKey:             apiKey,
-		BaseURL:            baseURL,
-		ChatModel:          chatModel,
-		TranscriptModel:    transcriptModel,
-		TTSModel:           ttsModel,
-		TTSVoice:           ttsVoice,
-		TranscriptLanguage: transcriptLanguage,
+		APIKey:          apiKey,
+		BaseURL:         baseURL,
+		ChatModel:       chatModel,
+		TranscriptModel: transcriptModel,
+		TTSModel:        ttsModel,
+		TTSVoice:        ttsVoice,
+		ModerationModel: moderationModel,
 	}
 }
 
+// TTSProvider digunakan untuk mendapatkan identitas provider dan model TTS
+func (c *OpenAI) TTSProvider() string {
+	return fmt.Sprintf("openai/%s", c.TTSModel)
+}
+
 // Chat digunakan untuk melakukan chat
 func (c *OpenAI) Chat(messages []ChatMessage) (ChatResponse, error) {
-	url, err := url.JoinPath(c.BaseURL, "/chat/completions")
-	if err != nil {
-		return ChatResponse{}, err
-	}
+	return c.chat(ChatRequest{
+		Model:    c.ChatModel,
+		Messages: messages,
+	})
+}
 
-	chatReq := ChatRequest{
+// StructuredChat digunakan untuk melakukan chat dengan jawaban JSON sesuai schema
+func (c *OpenAI) StructuredChat(messages []ChatMessage, schema JSONSchema) (ChatResponse, error) {
+	return c.chat(ChatRequest{
 		Model:    c.ChatModel,
 		Messages: messages,
+		ResponseFormat: &ResponseFormat{
+			Type:       "json_schema",
+			JSONSchema: &schema,
+		},
+	})
+}
+
+// chat digunakan untuk mengirim request chat completion
+func (c *OpenAI) chat(chatReq ChatRequest) (ChatResponse, error) {
+	url, err := url.JoinPath(c.BaseURL, "/chat/completions")
+	if err != nil {
+		return ChatResponse{}, err
 	}
 
 	body, err := json.Marshal(chatReq)
@@ -88,8 +113,12 @@ func (c *OpenAI) Chat(messages []ChatMessage) (ChatResponse, error) {
 	return chatResp, nil
 }
 
-// TextToSpeech digunakan untuk mengubah teks menjadi suara
-func (c *OpenAI) TextToSpeech(input string) (io.ReadCloser, error) {
+// TextToSpeech digunakan untuk mengubah teks menjadi suara, voice kosong berarti suara default
+func (c *OpenAI) TextToSpeech(input, voice string) (io.ReadCloser, error) {
+	if voice == "" {
+		voice = c.TTSVoice
+	}
+
 	url, err := url.JoinPath(c.BaseURL, "/audio/speech")
 	if err != nil {
 		return nil, err
@@ -97,7 +126,7 @@ func (c *OpenAI) TextToSpeech(input string) (io.ReadCloser, error) {
 
 	ttsReq := TTSRequest{
 		Model: c.TTSModel,
-		Voice: c.TTSVoice,
+		Voice: voice,
 		Input: input,
 	}
 
@@ -126,8 +155,9 @@ func (c *OpenAI) TextToSpeech(input string) (io.ReadCloser, error) {
 	return respBody, nil
 }
 
-// SpeechToText digunakan untuk mengubah suara menjadi teks
-func (c *OpenAI) Transcribe(file io.ReadCloser, filename string) (TranscriptResponse, error) {
+// Transcribe digunakan untuk mengubah suara menjadi teks,
+// bahasa akan dideteksi otomatis jika language bernilai auto atau kosong
+func (c *OpenAI) Transcribe(file io.ReadCloser, filename string, language Language) (TranscriptResponse, error) {
 	if file == nil {
 		return TranscriptResponse{}, fmt.Errorf("audio is nil")
 	}
@@ -156,11 +186,26 @@ func (c *OpenAI) Transcribe(file io.ReadCloser, filename string) (TranscriptResp
 		return TranscriptResponse{}, err
 	}
 
-	err = writer.WriteField("language", c.TranscriptLanguage)
+	// verbose_json menyertakan bahasa yang terdeteksi serta waktu kata dan segmen untuk analisis bicara
+	err = writer.WriteField("response_format", transcriptFormatVerbose)
 	if err != nil {
 		return TranscriptResponse{}, err
 	}
 
+	for _, granularity := range []string{"word", "segment"} {
+		err = writer.WriteField("timestamp_granularities[]", granularity)
+		if err != nil {
+			return TranscriptResponse{}, err
+		}
+	}
+
+	if !language.IsAuto() {
+		err = writer.WriteField("language", string(language))
+		if err != nil {
+			return TranscriptResponse{}, err
+		}
+	}
+
 	err = writer.Close()
 	if err != nil {
 		return TranscriptResponse{}, err
@@ -188,10 +233,59 @@ func (c *OpenAI) Transcribe(file io.ReadCloser, filename string) (TranscriptResp
 	if err != nil {
 		return TranscriptResponse{}, err
 	}
+	transcriptResp.Model = c.TranscriptModel
 
 	return transcriptResp, nil
 }
 
+// Moderate digunakan untuk mengecek apakah teks melanggar kebijakan konten
+func (c *OpenAI) Moderate(input string) (ModerationResponse, error) {
+	url, err := url.JoinPath(c.BaseURL, "/moderations")
+	if err != nil {
+		return ModerationResponse{}, err
+	}
+
+	moderationReq := ModerationRequest{
+		Model: c.ModerationModel,
+		Input: input,
+	}
+
+	body, err := json.Marshal(moderationReq)
+	if err != nil {
+		return ModerationResponse{}, err
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return ModerationResponse{}, err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return ModerationResponse{}, err
+	}
+
+	var moderationResp ModerationResponse
+	err = unmarshalJSONResponse(resp, &moderationResp)
+	if err != nil {
+		return ModerationResponse{}, err
+	}
+
+	return moderationResp, nil
+}
+
+// DecodeStructured digunakan untuk membaca jawaban JSON dari StructuredChat
+func DecodeStructured(resp ChatResponse, v any) error {
+	if len(resp.Choices) == 0 {
+		return fmt.Errorf("no chat completion")
+	}
+
+	return json.Unmarshal([]byte(resp.Choices[0].Message.Content), v)
+}
+
 // getResponseBody digunakan untuk mendapatkan response body dari http.Response
 func getResponseBody(resp *http.Response) (io.ReadCloser, error) {
 	if resp == nil || resp.Body == nil {
@@ -0,0 +1,73 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Language string
+
+const (
+	LANGUAGE_AUTO Language = "auto"
+	LANGUAGE_EN   Language = "en"
+	LANGUAGE_ID   Language = "id"
+)
+
+// DefaultLanguage digunakan ketika bahasa tidak ditentukan
+const DefaultLanguage = LANGUAGE_EN
+
+// languageNames berisi nama bahasa sesuai dengan field language pada verbose_json Whisper
+var languageNames = map[Language]string{
+	LANGUAGE_EN: "english",
+	LANGUAGE_ID: "indonesian",
+}
+
+// ParseLanguage digunakan untuk mengubah string menjadi bahasa yang didukung
+func ParseLanguage(s string) (Language, error) {
+	lang := Language(strings.ToLower(strings.TrimSpace(s)))
+	if lang == "" {
+		return DefaultLanguage, nil
+	}
+
+	if lang == LANGUAGE_AUTO {
+		return lang, nil
+	}
+
+	if _, ok := languageNames[lang]; !ok {
+		return "", fmt.Errorf("unsupported language: %s", s)
+	}
+
+	return lang, nil
+}
+
+// LanguageFromName digunakan untuk mengubah nama bahasa dari Whisper menjadi kode bahasa
+func LanguageFromName(name string) (Language, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for lang, langName := range languageNames {
+		if langName == name || string(lang) == name {
+			return lang, true
+		}
+	}
+
+	return "", false
+}
+
+// Languages digunakan untuk mendapatkan semua bahasa yang didukung
+func Languages() []Language {
+	return []Language{LANGUAGE_EN, LANGUAGE_ID}
+}
+
+// Name digunakan untuk mendapatkan nama bahasa yang bisa dibaca manusia
+func (l Language) Name() string {
+	name, ok := languageNames[l]
+	if !ok {
+		return string(l)
+	}
+
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// IsAuto digunakan untuk mengecek apakah bahasa perlu dideteksi otomatis
+func (l Language) IsAuto() bool {
+	return l == "" || l == LANGUAGE_AUTO
+}
@@ -1,17 +1,41 @@
 package ai
 
+import "encoding/json"
+
 type ChatRequest struct {
-	Messages []ChatMessage `json:"messages"`
-	Model    string        `json:"model"`
+	Messages       []ChatMessage   `json:"messages"`
+	Model          string          `json:"model"`
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+}
+
+type ResponseFormat struct {
+	Type       string      `json:"type"`
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+}
+
+type JSONSchema struct {
+	Name   string          `json:"name"`
+	Strict bool            `json:"strict"`
+	Schema json.RawMessage `json:"schema"`
 }
 
 type ChatResponse struct {
+	Model   string   `json:"model"`
 	Choices []Choice `json:"choices"`
+	Usage   Usage    `json:"usage"`
+}
+
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
 }
 
 type ChatMessage struct {
 	Content string `json:"content"`
 	Role    Role   `json:"role"`
+	// Name adalah identitas pembicara, misalnya persona interviewer di mode panel
+	Name string `json:"name,omitempty"`
 }
 
 type Role string
@@ -35,5 +59,42 @@ type TTSRequest struct {
 }
 
 type TranscriptResponse struct {
-	Text string `json:"text"`
+	Text     string `json:"text"`
+	Language string `json:"language,omitempty"`
+	// Duration adalah panjang audio dalam detik
+	Duration float64             `json:"duration,omitempty"`
+	Words    []TranscriptWord    `json:"words,omitempty"`
+	Segments []TranscriptSegment `json:"segments,omitempty"`
+
+	// Model diisi oleh client dengan model yang digunakan untuk transkripsi
+	Model string `json:"-"`
+}
+
+// TranscriptWord adalah satu kata hasil transkripsi beserta waktu mulai dan selesainya dalam detik
+type TranscriptWord struct {
+	Word  string  `json:"word"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// TranscriptSegment adalah satu potongan kalimat hasil transkripsi beserta waktunya dalam detik
+type TranscriptSegment struct {
+	ID    int     `json:"id"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Text  string  `json:"text"`
+}
+
+type ModerationRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
+
+type ModerationResponse struct {
+	Results []ModerationResult `json:"results"`
+}
+
+type ModerationResult struct {
+	Flagged    bool            `json:"flagged"`
+	Categories map[string]bool `json:"categories"`
 }
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+)
+
+var (
+	dbURI   = os.Getenv("DB_URI")
+	chatTTL = os.Getenv("CHAT_TTL")
+)
+
+// migrate digunakan untuk melengkapi waktu dibuat, waktu diubah, waktu kedaluwarsa, dan versi
+// dokumen chat lama, CHAT_TTL harus sama dengan server. Jalankan dengan `go run ./cmd/migrate`
+func main() {
+	if dbURI == "" {
+		log.Fatal("DB_URI is required")
+	}
+
+	// CHAT_TTL kosong atau 0 berarti chat lama disimpan tanpa batas waktu
+	var ttl time.Duration
+	if chatTTL != "" {
+		var err error
+		ttl, err = time.ParseDuration(chatTTL)
+		if err != nil || ttl < 0 {
+			log.Fatal("CHAT_TTL must be a non-negative duration")
+		}
+	}
+
+	migrated, err := data.NewMongo(dbURI).MigrateChats(ttl)
+	if err != nil {
+		log.Fatalf("failed to migrate chats: %v", err)
+	}
+
+	log.Printf("migrated %d chats", migrated)
+}
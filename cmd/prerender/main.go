@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+	"github.com/fastcampus-backend-golang/ai-interview/guard"
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+	"github.com/fastcampus-backend-golang/ai-interview/tts"
+)
+
+var (
+	apiKey      = os.Getenv("OPENAI_API_KEY")
+	ttsCacheDir = os.Getenv("TTS_CACHE_DIR")
+	templateDir = os.Getenv("TEMPLATE_DIR")
+)
+
+// prerender digunakan untuk membuat audio TTS dari teks pembuka dan balasan penolakan setiap template
+// dengan suara setiap persona ke cache disk,
+// jalankan dari root repository dengan `go run ./cmd/prerender`
+func main() {
+	if apiKey == "" {
+		log.Fatal("OPENAI_API_KEY is required")
+	}
+
+	if ttsCacheDir == "" {
+		ttsCacheDir = "cache/tts"
+	}
+
+	if templateDir == "" {
+		templateDir = "templates"
+	}
+
+	templates, err := interview.LoadTemplates(templateDir)
+	if err != nil {
+		log.Fatalf("failed to load templates: %v", err)
+	}
+
+	cache, err := tts.NewCache(tts.DefaultCacheSize, ttsCacheDir)
+	if err != nil {
+		log.Fatalf("failed to create TTS cache: %v", err)
+	}
+
+	openAI := ai.NewOpenAI(apiKey)
+	synthesizer := tts.NewSynthesizer(openAI, openAI.TTSProvider(), openAI.TTSVoice, cache)
+
+	// template dengan suara yang sama memakai audio yang sama sehingga cukup dibuat sekali
+	rendered := make(map[string]bool)
+	render := func(text, voice, line string) {
+		key, _, err := synthesizer.Synthesize(text, voice)
+		if err != nil {
+			log.Fatalf("failed to render %s: %v", line, err)
+		}
+
+		if !rendered[key] {
+			rendered[key] = true
+			log.Printf("rendered %s: %s", line, key)
+		}
+	}
+
+	for _, name := range templates.Names() {
+		panel := templates[name].Panel
+
+		// tanpa mode panel hanya ada satu interviewer dengan suara default
+		personas := panel.Personas
+		if !panel.Enabled() {
+			personas = []interview.Persona{{}}
+		}
+
+		for _, lang := range ai.Languages() {
+			asset, err := ai.GetChatAsset(lang)
+			if err != nil {
+				log.Fatalf("failed to get asset for %s: %v", lang, err)
+			}
+
+			// suara pembuka sama dengan yang dipakai StartChat, kosong berarti suara default
+			render(asset.ChatText, panel.Lead().Voice, fmt.Sprintf("%s opening line in %s", name, lang))
+
+			// setiap persona bisa menyampaikan balasan penolakan dengan suaranya sendiri
+			for _, persona := range personas {
+				render(guard.RefusalMessage(lang), persona.Voice, fmt.Sprintf("%s refusal by %q in %s", name, persona.ID, lang))
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+)
+
+var (
+	dbURI          = os.Getenv("DB_URI")
+	encryptionKeys = os.Getenv("ENCRYPTION_KEYS")
+	blobStore      = os.Getenv("BLOB_STORE")
+	blobDir        = os.Getenv("BLOB_DIR")
+)
+
+// blobLister adalah blob store yang bisa menampilkan semua ID blob
+type blobLister interface {
+	data.BlobStore
+	BlobIDs() ([]string, error)
+}
+
+// rotate-keys digunakan untuk mengenkripsi ulang kunci data chat, review, dan audio dengan kunci
+// pertama di ENCRYPTION_KEYS, kunci lama harus tetap ada di ENCRYPTION_KEYS selama proses berjalan,
+// jalankan dengan `go run ./cmd/rotate-keys`
+func main() {
+	if dbURI == "" {
+		log.Fatal("DB_URI is required")
+	}
+
+	keyring, err := data.ParseKeyring(encryptionKeys)
+	if err != nil {
+		log.Fatalf("failed to parse encryption keys: %v", err)
+	}
+
+	mongo := data.NewMongo(dbURI)
+
+	// enkripsi ulang kunci data setiap chat
+	chatIDs, err := mongo.ChatIDs()
+	if err != nil {
+		log.Fatalf("failed to list chats: %v", err)
+	}
+
+	chats := data.NewEncrypted(mongo, keyring)
+	rotated := 0
+	for _, id := range chatIDs {
+		changed, err := chats.Rekey(id)
+		if err != nil {
+			log.Printf("failed to rekey chat %s: %v", id, err)
+			continue
+		}
+		if changed {
+			rotated++
+		}
+	}
+	log.Printf("rekeyed %d of %d chats to key %s", rotated, len(chatIDs), keyring.CurrentKeyID())
+
+	// enkripsi ulang kunci data setiap review, termasuk review dari chat yang sudah dihapus
+	reviewIDs, err := mongo.ReviewChatIDs()
+	if err != nil {
+		log.Fatalf("failed to list reviews: %v", err)
+	}
+
+	reviews := data.NewEncryptedReviewStore(mongo, keyring)
+	rotated = 0
+	for _, id := range reviewIDs {
+		changed, err := reviews.Rekey(id)
+		if err != nil {
+			log.Printf("failed to rekey review %s: %v", id, err)
+			continue
+		}
+		if changed {
+			rotated++
+		}
+	}
+	log.Printf("rekeyed %d of %d reviews to key %s", rotated, len(reviewIDs), keyring.CurrentKeyID())
+
+	// enkripsi ulang kunci data setiap audio
+	var store blobLister
+	if blobStore == "fs" {
+		if blobDir == "" {
+			blobDir = "storage/audio"
+		}
+		store, err = data.NewFileSystem(blobDir)
+	} else {
+		store, err = data.NewGridFS(mongo)
+	}
+	if err != nil {
+		log.Fatalf("failed to create blob store: %v", err)
+	}
+
+	blobIDs, err := store.BlobIDs()
+	if err != nil {
+		log.Fatalf("failed to list audio: %v", err)
+	}
+
+	blobs := data.NewEncryptedBlobStore(store, keyring)
+	rotated = 0
+	for _, id := range blobIDs {
+		changed, err := blobs.Rekey(id)
+		if err != nil {
+			log.Printf("failed to rekey audio %s: %v", id, err)
+			continue
+		}
+		if changed {
+			rotated++
+		}
+	}
+	log.Printf("rekeyed %d of %d audio files to key %s", rotated, len(blobIDs), keyring.CurrentKeyID())
+}
@@ -0,0 +1,160 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/access"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrAccountNotFound dikembalikan ketika akun tidak ditemukan
+var ErrAccountNotFound = errors.New("account not found")
+
+// accountCollection adalah collection MongoDB untuk akun reviewer, admin, dan service
+const accountCollection = "account"
+
+// Account menyimpan akun selain kandidat yang mengakses API dengan token,
+// token hanya disimpan sebagai hash SHA-256 dalam hex
+type Account struct {
+	ID        string `bson:"_id"`
+	Name      string
+	Role      access.Role
+	TokenHash string
+	CreatedAt time.Time
+}
+
+// AccountStore digunakan untuk menyimpan akun beserta perannya
+type AccountStore interface {
+	InsertAccount(Account) (string, error)
+	// GetAccountByToken digunakan untuk mengambil akun berdasarkan hash token
+	GetAccountByToken(string) (Account, error)
+	ListAccounts() ([]Account, error)
+	DeleteAccount(string) error
+}
+
+func (m *Memory) InsertAccount(account Account) (string, error) {
+	if account.ID == "" {
+		account.ID = uuid.New().String()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.accounts[account.ID] = account
+
+	return account.ID, nil
+}
+
+func (m *Memory) GetAccountByToken(hash string) (Account, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, account := range m.accounts {
+		if account.TokenHash == hash {
+			return account, nil
+		}
+	}
+
+	return Account{}, ErrAccountNotFound
+}
+
+func (m *Memory) ListAccounts() ([]Account, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	accounts := make([]Account, 0, len(m.accounts))
+	for _, account := range m.accounts {
+		accounts = append(accounts, account)
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].CreatedAt.Before(accounts[j].CreatedAt)
+	})
+
+	return accounts, nil
+}
+
+func (m *Memory) DeleteAccount(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.accounts[id]; !ok {
+		return ErrAccountNotFound
+	}
+
+	delete(m.accounts, id)
+
+	return nil
+}
+
+// ensureAccountIndexes digunakan untuk memastikan satu token hanya dimiliki satu akun
+func (m *Mongo) ensureAccountIndexes() error {
+	token := mongo.IndexModel{
+		Keys:    bson.D{{Key: "tokenhash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err := m.db.Collection(accountCollection).Indexes().CreateOne(context.Background(), token)
+	return err
+}
+
+func (m *Mongo) InsertAccount(account Account) (string, error) {
+	if account.ID == "" {
+		account.ID = uuid.New().String()
+	}
+
+	_, err := m.db.Collection(accountCollection).InsertOne(context.Background(), account)
+	if err != nil {
+		return "", err
+	}
+
+	return account.ID, nil
+}
+
+func (m *Mongo) GetAccountByToken(hash string) (Account, error) {
+	var account Account
+	err := m.db.Collection(accountCollection).FindOne(context.Background(), bson.M{"tokenhash": hash}).Decode(&account)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Account{}, ErrAccountNotFound
+	}
+	if err != nil {
+		return Account{}, err
+	}
+
+	return account, nil
+}
+
+func (m *Mongo) ListAccounts() ([]Account, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdat", Value: 1}})
+
+	cursor, err := m.db.Collection(accountCollection).Find(context.Background(), bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := []Account{}
+	if err := cursor.All(context.Background(), &accounts); err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+func (m *Mongo) DeleteAccount(id string) error {
+	result, err := m.db.Collection(accountCollection).DeleteOne(context.Background(), bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return ErrAccountNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package data
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrBlobNotFound dikembalikan ketika blob tidak ditemukan
+var ErrBlobNotFound = errors.New("blob not found")
+
+type Blob struct {
+	ID          string
+	ChatID      string
+	ContentType string
+	Data        []byte
+	CreatedAt   time.Time
+
+	// Key adalah kunci data untuk membuka Data yang dienkripsi
+	Key *WrappedKey
+}
+
+type BlobStore interface {
+	PutBlob(Blob) (string, error)
+	GetBlob(string) (Blob, error)
+	DeleteBlob(string) error
+	// DeleteChatBlobs menghapus semua blob milik satu chat
+	DeleteChatBlobs(string) error
+	// SetBlobKey mengganti kunci data blob tanpa mengubah isinya
+	SetBlobKey(string, WrappedKey) error
+}
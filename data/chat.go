@@ -1,9 +1,187 @@
 package data
 
-import "github.com/fastcampus-backend-golang/ai-interview/ai"
+import (
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+	"github.com/fastcampus-backend-golang/ai-interview/design"
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+	"github.com/fastcampus-backend-golang/ai-interview/speech"
+)
 
 type ChatEntry struct {
-	ID      string `bson:"_id"`
-	Secret  string
-	History []ai.ChatMessage
+	ID string `bson:"_id"`
+	// Version dinaikkan setiap kali chat diubah untuk optimistic locking
+	Version       int64
+	Secret        string
+	Template      string
+	Language      ai.Language
+	RecordAnswers bool
+	Progress      interview.Progress
+	History       []Turn
+
+	// Questions berisi pertanyaan bank soal yang dipilih untuk chat ini
+	Questions interview.Questions `bson:",omitempty"`
+
+	// Scorecard berisi nilai gabungan jawaban user berdasarkan rubric template
+	Scorecard *interview.Scorecard `bson:",omitempty"`
+
+	// Shares berisi link baca saja yang dibuat pemilik chat
+	Shares []Share `bson:",omitempty"`
+
+	// Redactions berisi placeholder data pribadi dan nilai aslinya untuk ditampilkan
+	Redactions map[string]string `bson:",omitempty"`
+
+	// DataKey adalah kunci untuk membuka isi History dan Redactions yang dienkripsi
+	DataKey          *WrappedKey `bson:",omitempty"`
+	SealedRedactions *Sealed     `bson:",omitempty"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// ExpiresAt kosong berarti chat disimpan tanpa batas waktu
+	ExpiresAt time.Time `bson:",omitempty"`
+}
+
+// Migrate digunakan untuk melengkapi field dokumen lama yang dibuat sebelum chat menyimpan waktu dan
+// kedaluwarsa. Waktu dibuat dan diubah diambil dari giliran pertama dan terakhir, atau now jika tidak ada.
+// ExpiresAt dihitung dari waktu diubah ditambah ttl, ttl 0 berarti chat disimpan tanpa batas waktu
+func (e *ChatEntry) Migrate(ttl time.Duration, now time.Time) {
+	var first, last time.Time
+	for _, t := range e.History {
+		if t.CreatedAt.IsZero() {
+			continue
+		}
+		if first.IsZero() {
+			first = t.CreatedAt
+		}
+		last = t.CreatedAt
+	}
+
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = first
+		if e.CreatedAt.IsZero() {
+			e.CreatedAt = now
+		}
+	}
+
+	if e.UpdatedAt.IsZero() {
+		e.UpdatedAt = last
+		if e.UpdatedAt.IsZero() {
+			e.UpdatedAt = e.CreatedAt
+		}
+	}
+
+	if e.ExpiresAt.IsZero() && ttl > 0 {
+		e.ExpiresAt = e.UpdatedAt.Add(ttl)
+	}
+}
+
+// Recording menyimpan rekaman jawaban user untuk satu giliran
+type Recording struct {
+	Turn        int
+	BlobID      string
+	ContentType string
+	RecordedAt  time.Time
+	ExpiresAt   time.Time
+}
+
+// GetRecording digunakan untuk mengambil rekaman berdasarkan nomor giliran
+func (e ChatEntry) GetRecording(turn int) (Recording, bool) {
+	for _, t := range e.History {
+		if t.Recording != nil && t.Recording.Turn == turn {
+			return *t.Recording, true
+		}
+	}
+
+	return Recording{}, false
+}
+
+// AnswerCount digunakan untuk menghitung jumlah jawaban user di history
+func (e ChatEntry) AnswerCount() int {
+	count := 0
+	for _, t := range e.History {
+		if t.Role == ai.ROLE_USER {
+			count++
+		}
+	}
+
+	return count
+}
+
+// AnswerIndex digunakan untuk mendapatkan posisi jawaban ke-n di history, dimulai dari 1
+func (e ChatEntry) AnswerIndex(turn int) (int, bool) {
+	count := 0
+	for i, t := range e.History {
+		if t.Role != ai.ROLE_USER {
+			continue
+		}
+
+		count++
+		if count == turn {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// SpeechMetrics digunakan untuk mengambil analisis bicara dari semua jawaban lisan
+func (e ChatEntry) SpeechMetrics() []speech.Metrics {
+	var metrics []speech.Metrics
+	for _, t := range e.History {
+		if t.Role == ai.ROLE_USER && t.Speech != nil {
+			metrics = append(metrics, *t.Speech)
+		}
+	}
+
+	return metrics
+}
+
+// ExpireRecordings digunakan untuk melepaskan rekaman yang sudah kedaluwarsa dari history
+func (e *ChatEntry) ExpireRecordings(before time.Time) []Recording {
+	var expired []Recording
+	for i, t := range e.History {
+		if t.Recording != nil && !t.Recording.ExpiresAt.After(before) {
+			expired = append(expired, *t.Recording)
+			e.History[i].Recording = nil
+		}
+	}
+
+	return expired
+}
+
+// FlaggedAnswer adalah jawaban user yang ditandai oleh guard
+type FlaggedAnswer struct {
+	// Answer adalah nomor jawaban, sama dengan turn di scorecard
+	Answer int
+	Flags  []Flag
+}
+
+// FlaggedAnswers digunakan untuk mendapatkan jawaban yang ditandai oleh guard beserta nomornya
+func (e ChatEntry) FlaggedAnswers() []FlaggedAnswer {
+	var flagged []FlaggedAnswer
+	answer := 0
+	for _, t := range e.History {
+		if t.Role != ai.ROLE_USER {
+			continue
+		}
+
+		answer++
+		if len(t.Flags) > 0 {
+			flagged = append(flagged, FlaggedAnswer{Answer: answer, Flags: t.Flags})
+		}
+	}
+
+	return flagged
+}
+
+// LatestDesign digunakan untuk mengambil desain sistem terakhir yang dikirim user
+func (e ChatEntry) LatestDesign() (design.Design, time.Time, bool) {
+	for i := len(e.History) - 1; i >= 0; i-- {
+		if t := e.History[i]; t.Design != nil {
+			return *t.Design, t.CreatedAt, true
+		}
+	}
+
+	return design.Design{}, time.Time{}, false
 }
@@ -0,0 +1,70 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+)
+
+func TestChatEntryMigrate(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	first := now.Add(-48 * time.Hour)
+	last := now.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name          string
+		entry         ChatEntry
+		ttl           time.Duration
+		wantCreatedAt time.Time
+		wantUpdatedAt time.Time
+		wantExpiresAt time.Time
+	}{
+		{
+			name: "times are taken from the history",
+			entry: ChatEntry{History: []Turn{
+				{Role: ai.ROLE_SYSTEM},
+				{Role: ai.ROLE_ASSISTANT, CreatedAt: first},
+				{Role: ai.ROLE_USER, CreatedAt: last},
+			}},
+			ttl:           time.Hour,
+			wantCreatedAt: first,
+			wantUpdatedAt: last,
+			wantExpiresAt: last.Add(time.Hour),
+		},
+		{
+			name:          "history without times",
+			entry:         ChatEntry{History: []Turn{{Role: ai.ROLE_SYSTEM}, {Role: ai.ROLE_ASSISTANT}}},
+			ttl:           time.Hour,
+			wantCreatedAt: now,
+			wantUpdatedAt: now,
+			wantExpiresAt: now.Add(time.Hour),
+		},
+		{
+			name:          "zero ttl keeps the chat forever",
+			entry:         ChatEntry{History: []Turn{{Role: ai.ROLE_ASSISTANT, CreatedAt: first}}},
+			wantCreatedAt: first,
+			wantUpdatedAt: first,
+		},
+		{
+			name:          "existing times are kept",
+			entry:         ChatEntry{CreatedAt: first, UpdatedAt: last, ExpiresAt: now, History: []Turn{{Role: ai.ROLE_ASSISTANT, CreatedAt: now}}},
+			ttl:           time.Hour,
+			wantCreatedAt: first,
+			wantUpdatedAt: last,
+			wantExpiresAt: now,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := tt.entry
+			entry.Migrate(tt.ttl, now)
+
+			if !entry.CreatedAt.Equal(tt.wantCreatedAt) || !entry.UpdatedAt.Equal(tt.wantUpdatedAt) || !entry.ExpiresAt.Equal(tt.wantExpiresAt) {
+				t.Errorf("Migrate() times = %v, %v, %v, want %v, %v, %v",
+					entry.CreatedAt, entry.UpdatedAt, entry.ExpiresAt, tt.wantCreatedAt, tt.wantUpdatedAt, tt.wantExpiresAt)
+			}
+		})
+	}
+}
@@ -2,19 +2,39 @@ package data
 
 import (
 	"context"
+	"errors"
 	"log"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+var (
+	// ErrChatNotFound dikembalikan ketika chat tidak ditemukan
+	ErrChatNotFound = errors.New("chat not found")
+	// ErrConflict dikembalikan ketika chat sudah diubah oleh proses lain sejak dibaca
+	ErrConflict = errors.New("chat was modified concurrently")
+)
+
 type Client interface {
 	InsertChat(ChatEntry) (string, error)
 	GetChat(string) (ChatEntry, error)
+	// UpdateChat hanya berhasil jika Version sama dengan versi yang tersimpan,
+	// versi yang tersimpan akan dinaikkan satu setelah update berhasil
 	UpdateChat(string, ChatEntry) error
 	DeleteChat(string) error
+	ExpireRecordings(time.Time) ([]Recording, error)
+	ExpireChats(time.Time) ([]string, error)
+	// ListChats digunakan untuk mencari chat bagi admin sesuai filter
+	ListChats(ChatFilter) (ChatPage, error)
+	// ChatStats digunakan untuk meringkas chat yang dibuat di rentang waktu per template, waktu kosong berarti tanpa batas
+	ChatStats(from, to time.Time) ([]TemplateStats, error)
 }
 
 type Mongo struct {
@@ -24,6 +44,9 @@ type Mongo struct {
 const (
 	database   = "interview"
 	collection = "chat"
+
+	// chatTTLGrace memberi waktu bagi sweeper untuk menghapus audio sebelum MongoDB menghapus chat
+	chatTTLGrace = 24 * time.Hour
 )
 
 func NewMongo(uri string) *Mongo {
@@ -40,9 +63,49 @@ func NewMongo(uri string) *Mongo {
 		log.Fatalf("failed to ping MongoDB: %v", err)
 	}
 
-	return &Mongo{
+	m := &Mongo{
 		db: client.Database(database),
 	}
+
+	if err := m.ensureIndexes(); err != nil {
+		log.Fatalf("failed to create MongoDB indexes: %v", err)
+	}
+
+	if err := m.ensureAccountIndexes(); err != nil {
+		log.Fatalf("failed to create MongoDB account indexes: %v", err)
+	}
+
+	if err := m.ensureShareAccessIndexes(); err != nil {
+		log.Fatalf("failed to create MongoDB share access indexes: %v", err)
+	}
+
+	return m
+}
+
+// ensureIndexes digunakan untuk membuat index yang dibutuhkan collection chat
+func (m *Mongo) ensureIndexes() error {
+	// TTL index menghapus chat yang kedaluwarsa jika sweeper tidak berjalan
+	ttl := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expiresat", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(chatTTLGrace.Seconds())),
+	}
+
+	// text index untuk pencarian isi history oleh admin, giliran system ikut terindeks sehingga ListChats
+	// mengecek ulang kata di giliran lain. Bahasa chat disimpan di field language
+	// sehingga field lain dipakai sebagai penanda bahasa agar kode bahasa seperti "id" tidak ditolak.
+	// Ketika enkripsi aktif isi history kosong di database sehingga pencarian dilakukan oleh Encrypted
+	text := mongo.IndexModel{
+		Keys:    bson.D{{Key: "history.content", Value: "text"}},
+		Options: options.Index().SetDefaultLanguage("none").SetLanguageOverride("textlanguage"),
+	}
+
+	// index untuk daftar chat admin yang diurutkan dari yang paling baru
+	created := mongo.IndexModel{
+		Keys: bson.D{{Key: "createdat", Value: -1}, {Key: "_id", Value: -1}},
+	}
+
+	_, err := m.db.Collection(collection).Indexes().CreateMany(context.Background(), []mongo.IndexModel{ttl, text, created})
+	return err
 }
 
 func (m *Mongo) InsertChat(data ChatEntry) (string, error) {
@@ -62,6 +125,9 @@ func (m *Mongo) GetChat(id string) (ChatEntry, error) {
 	var data ChatEntry
 
 	err := m.db.Collection(collection).FindOne(context.Background(), bson.M{"_id": id}).Decode(&data)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ChatEntry{}, ErrChatNotFound
+	}
 	if err != nil {
 		return ChatEntry{}, err
 	}
@@ -70,11 +136,19 @@ func (m *Mongo) GetChat(id string) (ChatEntry, error) {
 }
 
 func (m *Mongo) UpdateChat(id string, data ChatEntry) error {
-	_, err := m.db.Collection(collection).UpdateOne(context.Background(), bson.M{"_id": id}, bson.M{"$set": data})
+	filter := versionFilter(id, data.Version)
+	data.Version++
+
+	// replace agar field omitempty yang dikosongkan ikut terhapus dari dokumen
+	result, err := m.db.Collection(collection).ReplaceOne(context.Background(), filter, data)
 	if err != nil {
 		return err
 	}
 
+	if result.MatchedCount == 0 {
+		return ErrConflict
+	}
+
 	return nil
 }
 
@@ -86,3 +160,306 @@ func (m *Mongo) DeleteChat(id string) error {
 
 	return nil
 }
+
+// ExpireRecordings digunakan untuk menghapus rekaman yang sudah kedaluwarsa dari chat,
+// rekaman yang dihapus dikembalikan agar blob audionya bisa ikut dihapus
+func (m *Mongo) ExpireRecordings(before time.Time) ([]Recording, error) {
+	filter := bson.M{"history.recording.expiresat": bson.M{"$lte": before}}
+
+	cursor, err := m.db.Collection(collection).Find(context.Background(), filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var expired []Recording
+	for cursor.Next(context.Background()) {
+		var data ChatEntry
+		if err := cursor.Decode(&data); err != nil {
+			return expired, err
+		}
+
+		removed := data.ExpireRecordings(before)
+
+		// chat yang sedang diubah dilewati dan diproses pada sweep berikutnya
+		err := m.UpdateChat(data.ID, data)
+		if errors.Is(err, ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+
+		expired = append(expired, removed...)
+	}
+
+	return expired, cursor.Err()
+}
+
+// ExpireChats digunakan untuk menghapus chat yang sudah kedaluwarsa,
+// ID chat yang dihapus dikembalikan agar audionya bisa ikut dihapus
+func (m *Mongo) ExpireChats(before time.Time) ([]string, error) {
+	filter := bson.M{"expiresat": bson.M{"$lte": before}}
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+
+	cursor, err := m.db.Collection(collection).Find(context.Background(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var ids []string
+	for cursor.Next(context.Background()) {
+		var data struct {
+			ID string `bson:"_id"`
+		}
+		if err := cursor.Decode(&data); err != nil {
+			return ids, err
+		}
+		ids = append(ids, data.ID)
+	}
+	if err := cursor.Err(); err != nil {
+		return ids, err
+	}
+
+	// cek ulang waktu kedaluwarsa agar chat yang baru diperpanjang tidak ikut terhapus,
+	// hanya chat yang benar-benar dihapus yang dikembalikan agar audio dan review-nya tetap ada
+	var deleted []string
+	for _, id := range ids {
+		filter := bson.M{"_id": id, "expiresat": bson.M{"$lte": before}}
+		result, err := m.db.Collection(collection).DeleteOne(context.Background(), filter)
+		if err != nil {
+			return deleted, err
+		}
+		if result.DeletedCount > 0 {
+			deleted = append(deleted, id)
+		}
+	}
+
+	return deleted, nil
+}
+
+// ChatIDs digunakan untuk mendapatkan ID semua chat tanpa membaca isinya
+func (m *Mongo) ChatIDs() ([]string, error) {
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+
+	cursor, err := m.db.Collection(collection).Find(context.Background(), bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var ids []string
+	for cursor.Next(context.Background()) {
+		var data struct {
+			ID string `bson:"_id"`
+		}
+		if err := cursor.Decode(&data); err != nil {
+			return ids, err
+		}
+		ids = append(ids, data.ID)
+	}
+
+	return ids, cursor.Err()
+}
+
+// MigrateChats digunakan untuk melengkapi dokumen chat lama tanpa createdat, updatedat, atau version
+// dengan ChatEntry.Migrate, version diisi saat dokumen disimpan. Mengembalikan jumlah dokumen yang diubah
+func (m *Mongo) MigrateChats(ttl time.Duration) (int, error) {
+	filter := bson.M{"$or": []bson.M{
+		{"createdat": bson.M{"$exists": false}},
+		{"updatedat": bson.M{"$exists": false}},
+		{"version": bson.M{"$exists": false}},
+	}}
+
+	cursor, err := m.db.Collection(collection).Find(context.Background(), filter)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(context.Background())
+
+	now := time.Now()
+	migrated := 0
+	for cursor.Next(context.Background()) {
+		var data ChatEntry
+		if err := cursor.Decode(&data); err != nil {
+			return migrated, err
+		}
+
+		data.Migrate(ttl, now)
+
+		// chat yang diubah bersamaan dilewati dan dimigrasi saat perintah dijalankan ulang
+		err := m.UpdateChat(data.ID, data)
+		if errors.Is(err, ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return migrated, err
+		}
+
+		migrated++
+	}
+
+	return migrated, cursor.Err()
+}
+
+// versionFilter digunakan untuk membuat filter compare-and-swap berdasarkan versi,
+// dokumen lama tanpa field version dianggap versi 0
+func versionFilter(id string, version int64) bson.M {
+	if version == 0 {
+		return bson.M{"_id": id, "$or": []bson.M{
+			{"version": 0},
+			{"version": bson.M{"$exists": false}},
+		}}
+	}
+
+	return bson.M{"_id": id, "version": version}
+}
+
+func (m *Mongo) ListChats(f ChatFilter) (ChatPage, error) {
+	filter := bson.M{}
+	if f.Template != "" {
+		filter["template"] = f.Template
+	}
+	if f.Finished != nil {
+		filter["progress.finished"] = *f.Finished
+	}
+
+	created := bson.M{}
+	if !f.From.IsZero() {
+		created["$gte"] = f.From
+	}
+	if !f.To.IsZero() {
+		created["$lt"] = f.To
+	}
+	if len(created) > 0 {
+		filter["createdat"] = created
+	}
+
+	score := bson.M{}
+	if f.MinScore != nil {
+		score["$gte"] = *f.MinScore
+	}
+	if f.MaxScore != nil {
+		score["$lte"] = *f.MaxScore
+	}
+	if len(score) > 0 {
+		filter["scorecard.overall"] = score
+	}
+
+	// setiap kata dicari sebagai frasa agar semua kata harus muncul seperti pada Memory.
+	// Text index juga berisi prompt system sehingga setiap kata dicek ulang hanya di giliran selain system
+	if terms := SearchTerms(f.Query); len(terms) > 0 {
+		phrases := make([]string, len(terms))
+		turns := make(bson.A, len(terms))
+		for i, term := range terms {
+			phrases[i] = `"` + strings.ReplaceAll(term, `"`, "") + `"`
+			turns[i] = bson.M{"history": bson.M{"$elemMatch": bson.M{
+				"role":    bson.M{"$ne": ai.ROLE_SYSTEM},
+				"content": bson.M{"$regex": regexp.QuoteMeta(term), "$options": "i"},
+			}}}
+		}
+		filter["$text"] = bson.M{"$search": strings.Join(phrases, " ")}
+		filter["$and"] = turns
+	}
+
+	if f.After != nil {
+		filter["$or"] = bson.A{
+			bson.M{"createdat": bson.M{"$lt": f.After.CreatedAt}},
+			bson.M{"createdat": f.After.CreatedAt, "_id": bson.M{"$lt": f.After.ID}},
+		}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdat", Value: -1}, {Key: "_id", Value: -1}})
+	if f.Limit > 0 {
+		opts.SetLimit(int64(f.Limit))
+	}
+
+	cursor, err := m.db.Collection(collection).Find(context.Background(), filter, opts)
+	if err != nil {
+		return ChatPage{}, err
+	}
+	defer cursor.Close(context.Background())
+
+	var chats []ChatEntry
+	for cursor.Next(context.Background()) {
+		var data ChatEntry
+		if err := cursor.Decode(&data); err != nil {
+			return ChatPage{}, err
+		}
+
+		chats = append(chats, data)
+	}
+	if err := cursor.Err(); err != nil {
+		return ChatPage{}, err
+	}
+
+	return pageOf(chats, f.Limit), nil
+}
+
+func (m *Mongo) ChatStats(from, to time.Time) ([]TemplateStats, error) {
+	match := bson.M{}
+	created := bson.M{}
+	if !from.IsZero() {
+		created["$gte"] = from
+	}
+	if !to.IsZero() {
+		created["$lt"] = to
+	}
+	if len(created) > 0 {
+		match["createdat"] = created
+	}
+
+	answers := bson.M{"$size": bson.M{"$filter": bson.M{
+		"input": bson.M{"$ifNull": bson.A{"$history", bson.A{}}},
+		"cond":  bson.M{"$eq": bson.A{"$$this.role", "user"}},
+	}}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":            "$template",
+			"chats":          bson.M{"$sum": 1},
+			"finished":       bson.M{"$sum": bson.M{"$cond": bson.A{"$progress.finished", 1, 0}}},
+			"scored":         bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{"$scorecard", nil}}, 1, 0}}},
+			"averagescore":   bson.M{"$avg": "$scorecard.overall"},
+			"averageanswers": bson.M{"$avg": answers},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := m.db.Collection(collection).Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var stats []TemplateStats
+	for cursor.Next(context.Background()) {
+		var data struct {
+			Template       string   `bson:"_id"`
+			Chats          int      `bson:"chats"`
+			Finished       int      `bson:"finished"`
+			Scored         int      `bson:"scored"`
+			AverageScore   *float64 `bson:"averagescore"`
+			AverageAnswers float64  `bson:"averageanswers"`
+		}
+		if err := cursor.Decode(&data); err != nil {
+			return stats, err
+		}
+
+		stat := TemplateStats{
+			Template:       data.Template,
+			Chats:          data.Chats,
+			Finished:       data.Finished,
+			Scored:         data.Scored,
+			AverageAnswers: data.AverageAnswers,
+		}
+		if data.AverageScore != nil {
+			stat.AverageScore = *data.AverageScore
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, cursor.Err()
+}
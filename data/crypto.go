@@ -0,0 +1,178 @@
+package data
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// dataKeySize adalah ukuran kunci AES-256
+const dataKeySize = 32
+
+// ErrUnknownKey dikembalikan ketika data dienkripsi dengan kunci yang tidak ada di keyring
+var ErrUnknownKey = errors.New("unknown encryption key")
+
+// Keyring berisi kunci enkripsi utama (KEK), kunci pertama dipakai untuk enkripsi baru
+// dan kunci lainnya hanya dipakai untuk membuka data lama
+type Keyring struct {
+	current string
+	keys    map[string][]byte
+}
+
+// WrappedKey adalah kunci data (DEK) yang dienkripsi dengan kunci utama
+type WrappedKey struct {
+	KeyID      string
+	Nonce      []byte
+	Ciphertext []byte
+}
+
+// Sealed adalah data yang dienkripsi dengan kunci data
+type Sealed struct {
+	Nonce      []byte
+	Ciphertext []byte
+}
+
+// ParseKeyring digunakan untuk membaca keyring dengan format "id:base64,id:base64"
+func ParseKeyring(s string) (*Keyring, error) {
+	keyring := &Keyring{keys: make(map[string][]byte)}
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		id, encoded, ok := strings.Cut(part, ":")
+		if !ok || id == "" {
+			return nil, errors.New("encryption key must be in id:base64 format")
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %s: %w", id, err)
+		}
+		if len(key) != dataKeySize {
+			return nil, fmt.Errorf("encryption key %s must be %d bytes", id, dataKeySize)
+		}
+		if _, ok := keyring.keys[id]; ok {
+			return nil, fmt.Errorf("duplicate encryption key %s", id)
+		}
+
+		if keyring.current == "" {
+			keyring.current = id
+		}
+		keyring.keys[id] = key
+	}
+
+	if keyring.current == "" {
+		return nil, errors.New("at least one encryption key is required")
+	}
+
+	return keyring, nil
+}
+
+// CurrentKeyID digunakan untuk mendapatkan ID kunci yang dipakai untuk enkripsi baru
+func (k *Keyring) CurrentKeyID() string {
+	return k.current
+}
+
+// newDataKey digunakan untuk membuat kunci data baru beserta versi terenkripsinya
+func (k *Keyring) newDataKey() ([]byte, WrappedKey, error) {
+	dek := make([]byte, dataKeySize)
+	if _, err := rand.Read(dek); err != nil {
+		return nil, WrappedKey{}, err
+	}
+
+	wrapped, err := k.wrap(dek)
+	if err != nil {
+		return nil, WrappedKey{}, err
+	}
+
+	return dek, wrapped, nil
+}
+
+// wrap digunakan untuk mengenkripsi kunci data dengan kunci utama saat ini
+func (k *Keyring) wrap(dek []byte) (WrappedKey, error) {
+	sealed, err := seal(k.keys[k.current], dek, []byte(k.current))
+	if err != nil {
+		return WrappedKey{}, err
+	}
+
+	return WrappedKey{
+		KeyID:      k.current,
+		Nonce:      sealed.Nonce,
+		Ciphertext: sealed.Ciphertext,
+	}, nil
+}
+
+// unwrap digunakan untuk membuka kunci data dengan kunci utama yang sesuai
+func (k *Keyring) unwrap(wrapped WrappedKey) ([]byte, error) {
+	kek, ok := k.keys[wrapped.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, wrapped.KeyID)
+	}
+
+	return open(kek, Sealed{Nonce: wrapped.Nonce, Ciphertext: wrapped.Ciphertext}, []byte(wrapped.KeyID))
+}
+
+// rewrap digunakan untuk mengenkripsi ulang kunci data dengan kunci utama saat ini,
+// mengembalikan false jika kunci data sudah memakai kunci utama saat ini
+func (k *Keyring) rewrap(wrapped WrappedKey) (WrappedKey, bool, error) {
+	if wrapped.KeyID == k.current {
+		return wrapped, false, nil
+	}
+
+	dek, err := k.unwrap(wrapped)
+	if err != nil {
+		return WrappedKey{}, false, err
+	}
+
+	rewrapped, err := k.wrap(dek)
+	if err != nil {
+		return WrappedKey{}, false, err
+	}
+
+	return rewrapped, true, nil
+}
+
+// seal digunakan untuk mengenkripsi data dengan AES-GCM,
+// aad mengikat ciphertext ke konteksnya agar tidak bisa dipindahkan
+func seal(key, plaintext, aad []byte) (Sealed, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return Sealed{}, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return Sealed{}, err
+	}
+
+	return Sealed{
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, aad),
+	}, nil
+}
+
+// open digunakan untuk membuka data yang dienkripsi dengan seal
+func open(key []byte, sealed Sealed, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return gcm.Open(nil, sealed.Nonce, sealed.Ciphertext, aad)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
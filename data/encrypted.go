@@ -0,0 +1,305 @@
+package data
+
+import (
+	"log"
+
+	"github.com/fastcampus-backend-golang/ai-interview/design"
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// maxSearchScan adalah jumlah chat terbanyak yang dibuka untuk mencari isi history dalam satu halaman
+	maxSearchScan = 500
+	// searchBatchSize adalah jumlah chat yang diambil dari database setiap kali pencarian melanjutkan scan
+	searchBatchSize = 100
+)
+
+// Encrypted membungkus Client agar isi history dienkripsi sebelum disimpan,
+// metadata seperti waktu, model, dan rekaman tetap bisa dibaca tanpa dekripsi
+// sehingga ExpireRecordings dan ExpireChats diteruskan apa adanya
+type Encrypted struct {
+	Client
+	keyring *Keyring
+}
+
+// sealedContent adalah field giliran yang dienkripsi
+type sealedContent struct {
+	Content    string `bson:"content,omitempty"`
+	Transcript string `bson:"transcript,omitempty"`
+	SpeechText string `bson:"speechText,omitempty"`
+	Code       string `bson:"code,omitempty"`
+	Output     string `bson:"output,omitempty"`
+
+	Design *design.Design    `bson:"design,omitempty"`
+	Scores []interview.Score `bson:"scores,omitempty"`
+	STAR   *interview.STAR   `bson:"star,omitempty"`
+
+	Ideal *interview.IdealAnswer `bson:"ideal,omitempty"`
+}
+
+// sealedRedactions adalah placeholder data pribadi yang dienkripsi
+type sealedRedactions struct {
+	Values map[string]string `bson:"values"`
+}
+
+// NewEncrypted digunakan untuk membuat client yang mengenkripsi history chat
+func NewEncrypted(client Client, keyring *Keyring) *Encrypted {
+	return &Encrypted{
+		Client:  client,
+		keyring: keyring,
+	}
+}
+
+func (e *Encrypted) InsertChat(data ChatEntry) (string, error) {
+	// ID dibutuhkan sebagai konteks enkripsi
+	if data.ID == "" {
+		data.ID = uuid.New().String()
+	}
+
+	if err := e.sealHistory(&data); err != nil {
+		return "", err
+	}
+
+	return e.Client.InsertChat(data)
+}
+
+func (e *Encrypted) GetChat(id string) (ChatEntry, error) {
+	data, err := e.Client.GetChat(id)
+	if err != nil {
+		return ChatEntry{}, err
+	}
+
+	if err := e.openHistory(&data); err != nil {
+		return ChatEntry{}, err
+	}
+
+	return data, nil
+}
+
+func (e *Encrypted) UpdateChat(id string, data ChatEntry) error {
+	data.ID = id
+	if err := e.sealHistory(&data); err != nil {
+		return err
+	}
+
+	return e.Client.UpdateChat(id, data)
+}
+
+// ListChats mencari isi history setelah dibuka karena isi yang terenkripsi tidak bisa dicari oleh database.
+// Tanpa query, halaman dari database dikembalikan tanpa dibuka karena daftar chat hanya memakai metadata.
+// Chat diambil per batch dan paling banyak maxSearchScan chat dibuka per halaman agar satu pencarian
+// tidak membuka seluruh collection. Jika batas tercapai sebelum halaman penuh, halaman berisi chat yang
+// cocok sejauh ini dan Next menunjuk chat terakhir yang diperiksa. Chat yang gagal dibuka, misalnya karena
+// kuncinya sudah dihapus dari keyring, dilewati agar tidak menggagalkan seluruh halaman
+func (e *Encrypted) ListChats(f ChatFilter) (ChatPage, error) {
+	query := f.Query
+	if len(SearchTerms(query)) == 0 {
+		return e.Client.ListChats(f)
+	}
+	f.Query = ""
+
+	limit := f.Limit
+	var page ChatPage
+	for scanned := 0; scanned < maxSearchScan; {
+		f.Limit = min(searchBatchSize, maxSearchScan-scanned)
+		batch, err := e.Client.ListChats(f)
+		if err != nil {
+			return ChatPage{}, err
+		}
+
+		for _, data := range batch.Chats {
+			scanned++
+			cursor := CursorOf(data)
+			f.After = &cursor
+
+			if err := e.openHistory(&data); err != nil {
+				log.Printf("skipping chat %s in search: %v", data.ID, err)
+				continue
+			}
+			if !data.Contains(query) {
+				continue
+			}
+
+			page.Chats = append(page.Chats, data)
+			if limit > 0 && len(page.Chats) == limit {
+				page.Next = &cursor
+				return page, nil
+			}
+		}
+
+		// batch terakhir berarti semua chat yang cocok sudah diambil
+		if batch.Next == nil {
+			return page, nil
+		}
+	}
+
+	page.Next = f.After
+
+	return page, nil
+}
+
+// Rekey digunakan untuk mengenkripsi ulang kunci data chat dengan kunci utama saat ini,
+// chat lama yang belum terenkripsi akan dienkripsi seluruhnya
+func (e *Encrypted) Rekey(id string) (bool, error) {
+	raw, err := e.Client.GetChat(id)
+	if err != nil {
+		return false, err
+	}
+
+	// chat lama tanpa kunci data dienkripsi melalui UpdateChat
+	if raw.DataKey == nil {
+		return true, e.UpdateChat(id, raw)
+	}
+
+	wrapped, changed, err := e.keyring.rewrap(*raw.DataKey)
+	if err != nil || !changed {
+		return false, err
+	}
+
+	// isi history tidak perlu dienkripsi ulang karena kunci datanya tetap sama
+	raw.DataKey = &wrapped
+
+	return true, e.Client.UpdateChat(id, raw)
+}
+
+// sealHistory digunakan untuk mengenkripsi isi setiap giliran di history
+func (e *Encrypted) sealHistory(data *ChatEntry) error {
+	var dek []byte
+	var err error
+
+	if data.DataKey != nil {
+		dek, err = e.keyring.unwrap(*data.DataKey)
+	} else {
+		var wrapped WrappedKey
+		dek, wrapped, err = e.keyring.newDataKey()
+		data.DataKey = &wrapped
+	}
+	if err != nil {
+		return err
+	}
+
+	history := make([]Turn, len(data.History))
+	for i, t := range data.History {
+		// giliran yang belum dibuka tetap disimpan apa adanya
+		if t.Sealed != nil {
+			history[i] = t
+			continue
+		}
+
+		content := sealedContent{
+			Content:    t.Content,
+			Transcript: t.Transcript,
+			SpeechText: t.SpeechText,
+			Design:     t.Design,
+			Scores:     t.Scores,
+			STAR:       t.STAR,
+			Ideal:      t.Ideal,
+		}
+		if t.Submission != nil {
+			content.Code = t.Submission.Code
+			content.Output = t.Submission.Output
+		}
+
+		plaintext, err := bson.Marshal(content)
+		if err != nil {
+			return err
+		}
+
+		sealed, err := seal(dek, plaintext, []byte(data.ID))
+		if err != nil {
+			return err
+		}
+
+		t.Content, t.Transcript, t.SpeechText = "", "", ""
+		t.Design, t.Scores, t.STAR, t.Ideal = nil, nil, nil, nil
+		if t.Submission != nil {
+			submission := *t.Submission
+			submission.Code, submission.Output = "", ""
+			t.Submission = &submission
+		}
+		t.Sealed = &sealed
+		history[i] = t
+	}
+	data.History = history
+
+	// nilai asli data pribadi ikut dienkripsi
+	if len(data.Redactions) > 0 {
+		plaintext, err := bson.Marshal(sealedRedactions{Values: data.Redactions})
+		if err != nil {
+			return err
+		}
+
+		sealed, err := seal(dek, plaintext, []byte(data.ID))
+		if err != nil {
+			return err
+		}
+
+		data.Redactions = nil
+		data.SealedRedactions = &sealed
+	}
+
+	return nil
+}
+
+// openHistory digunakan untuk membuka isi setiap giliran yang dienkripsi
+func (e *Encrypted) openHistory(data *ChatEntry) error {
+	if data.DataKey == nil {
+		return nil
+	}
+
+	dek, err := e.keyring.unwrap(*data.DataKey)
+	if err != nil {
+		return err
+	}
+
+	for i, t := range data.History {
+		if t.Sealed == nil {
+			continue
+		}
+
+		plaintext, err := open(dek, *t.Sealed, []byte(data.ID))
+		if err != nil {
+			return err
+		}
+
+		var content sealedContent
+		if err := bson.Unmarshal(plaintext, &content); err != nil {
+			return err
+		}
+
+		data.History[i].Content = content.Content
+		data.History[i].Transcript = content.Transcript
+		data.History[i].SpeechText = content.SpeechText
+		data.History[i].Design = content.Design
+		data.History[i].Scores = content.Scores
+		data.History[i].STAR = content.STAR
+		data.History[i].Ideal = content.Ideal
+		data.History[i].Sealed = nil
+
+		// salin submission agar data yang tersimpan tidak ikut berubah
+		if t.Submission != nil {
+			submission := *t.Submission
+			submission.Code, submission.Output = content.Code, content.Output
+			data.History[i].Submission = &submission
+		}
+	}
+
+	if data.SealedRedactions != nil {
+		plaintext, err := open(dek, *data.SealedRedactions, []byte(data.ID))
+		if err != nil {
+			return err
+		}
+
+		var redactions sealedRedactions
+		if err := bson.Unmarshal(plaintext, &redactions); err != nil {
+			return err
+		}
+
+		data.Redactions = redactions.Values
+		data.SealedRedactions = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// EncryptedBlobStore membungkus BlobStore agar isi blob dienkripsi sebelum disimpan
+type EncryptedBlobStore struct {
+	BlobStore
+	keyring *Keyring
+}
+
+// NewEncryptedBlobStore digunakan untuk membuat blob store yang mengenkripsi isi blob
+func NewEncryptedBlobStore(store BlobStore, keyring *Keyring) *EncryptedBlobStore {
+	return &EncryptedBlobStore{
+		BlobStore: store,
+		keyring:   keyring,
+	}
+}
+
+func (e *EncryptedBlobStore) PutBlob(blob Blob) (string, error) {
+	// ID dibutuhkan sebagai konteks enkripsi
+	if blob.ID == "" {
+		blob.ID = uuid.New().String()
+	}
+
+	dek, wrapped, err := e.keyring.newDataKey()
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := seal(dek, blob.Data, []byte(blob.ID))
+	if err != nil {
+		return "", err
+	}
+
+	// nonce disimpan di depan ciphertext
+	blob.Data = append(sealed.Nonce, sealed.Ciphertext...)
+	blob.Key = &wrapped
+
+	return e.BlobStore.PutBlob(blob)
+}
+
+func (e *EncryptedBlobStore) GetBlob(id string) (Blob, error) {
+	blob, err := e.BlobStore.GetBlob(id)
+	if err != nil {
+		return Blob{}, err
+	}
+
+	// blob lama tanpa kunci data belum dienkripsi
+	if blob.Key == nil {
+		return blob, nil
+	}
+
+	dek, err := e.keyring.unwrap(*blob.Key)
+	if err != nil {
+		return Blob{}, err
+	}
+
+	gcm, err := newGCM(dek)
+	if err != nil {
+		return Blob{}, err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(blob.Data) < nonceSize {
+		return Blob{}, ErrBlobNotFound
+	}
+
+	plaintext, err := open(dek, Sealed{Nonce: blob.Data[:nonceSize], Ciphertext: blob.Data[nonceSize:]}, []byte(id))
+	if err != nil {
+		return Blob{}, err
+	}
+
+	blob.Data = plaintext
+	blob.Key = nil
+
+	return blob, nil
+}
+
+// backupBlobSuffix ditambahkan ke ID salinan blob yang dibuat selama blob lama dienkripsi ulang
+const backupBlobSuffix = "-backup"
+
+// Rekey digunakan untuk mengenkripsi ulang kunci data blob dengan kunci utama saat ini,
+// blob lama yang belum terenkripsi akan ditulis ulang dalam bentuk terenkripsi
+func (e *EncryptedBlobStore) Rekey(id string) (bool, error) {
+	// salinan dari proses yang gagal hanya dipakai untuk memulihkan blob aslinya
+	if strings.HasSuffix(id, backupBlobSuffix) {
+		return false, nil
+	}
+
+	raw, err := e.BlobStore.GetBlob(id)
+	if err != nil {
+		return false, err
+	}
+
+	if raw.Key == nil {
+		return true, e.encryptInPlace(raw)
+	}
+
+	wrapped, changed, err := e.keyring.rewrap(*raw.Key)
+	if err != nil || !changed {
+		return false, err
+	}
+
+	return true, e.BlobStore.SetBlobKey(id, wrapped)
+}
+
+// encryptInPlace digunakan untuk menulis ulang blob yang belum terenkripsi dengan ID yang sama.
+// Blob store tidak mendukung penimpaan, jadi salinan terenkripsi disimpan lebih dulu sebelum
+// blob lama dihapus agar audio tidak hilang jika penulisan ulang gagal
+func (e *EncryptedBlobStore) encryptInPlace(raw Blob) error {
+	backup := raw
+	backup.ID = raw.ID + backupBlobSuffix
+
+	// buang salinan dari proses sebelumnya yang gagal sebelum menulis yang baru
+	if err := e.BlobStore.DeleteBlob(backup.ID); err != nil && !errors.Is(err, ErrBlobNotFound) {
+		return err
+	}
+	if _, err := e.PutBlob(backup); err != nil {
+		return err
+	}
+
+	if err := e.BlobStore.DeleteBlob(raw.ID); err != nil {
+		return err
+	}
+	if _, err := e.PutBlob(raw); err != nil {
+		return fmt.Errorf("blob is kept as %s: %w", backup.ID, err)
+	}
+
+	return e.BlobStore.DeleteBlob(backup.ID)
+}
@@ -0,0 +1,99 @@
+package data
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// failingPutStore menolak penulisan blob dengan ID tertentu
+type failingPutStore struct {
+	BlobStore
+	failID string
+}
+
+func (f *failingPutStore) PutBlob(blob Blob) (string, error) {
+	if blob.ID == f.failID {
+		return "", errors.New("disk full")
+	}
+
+	return f.BlobStore.PutBlob(blob)
+}
+
+func newTestKeyring(t *testing.T) *Keyring {
+	t.Helper()
+
+	keyring, err := ParseKeyring("k1:" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, dataKeySize)))
+	if err != nil {
+		t.Fatalf("failed to parse keyring: %v", err)
+	}
+
+	return keyring
+}
+
+func TestRekeyEncryptsLegacyBlob(t *testing.T) {
+	store, err := NewFileSystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create blob store: %v", err)
+	}
+
+	audio := []byte("legacy audio")
+	id, err := store.PutBlob(Blob{ChatID: "chat", ContentType: "audio/mpeg", Data: audio})
+	if err != nil {
+		t.Fatalf("failed to put blob: %v", err)
+	}
+
+	encrypted := NewEncryptedBlobStore(store, newTestKeyring(t))
+	changed, err := encrypted.Rekey(id)
+	if err != nil || !changed {
+		t.Fatalf("Rekey() = %v, %v, want true, nil", changed, err)
+	}
+
+	raw, err := store.GetBlob(id)
+	if err != nil {
+		t.Fatalf("failed to get raw blob: %v", err)
+	}
+	if raw.Key == nil || bytes.Contains(raw.Data, audio) {
+		t.Errorf("blob is still stored in plaintext")
+	}
+
+	blob, err := encrypted.GetBlob(id)
+	if err != nil || !bytes.Equal(blob.Data, audio) {
+		t.Errorf("GetBlob() = %q, %v, want %q", blob.Data, err, audio)
+	}
+
+	if _, err := store.GetBlob(id + backupBlobSuffix); !errors.Is(err, ErrBlobNotFound) {
+		t.Errorf("backup blob was not deleted: %v", err)
+	}
+}
+
+func TestRekeyKeepsBackupWhenRewriteFails(t *testing.T) {
+	fs, err := NewFileSystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create blob store: %v", err)
+	}
+
+	audio := []byte("legacy audio")
+	id, err := fs.PutBlob(Blob{ChatID: "chat", ContentType: "audio/mpeg", Data: audio})
+	if err != nil {
+		t.Fatalf("failed to put blob: %v", err)
+	}
+
+	encrypted := NewEncryptedBlobStore(&failingPutStore{BlobStore: fs, failID: id}, newTestKeyring(t))
+	if _, err := encrypted.Rekey(id); err == nil || !strings.Contains(err.Error(), id+backupBlobSuffix) {
+		t.Fatalf("Rekey() error = %v, want error naming the backup blob", err)
+	}
+
+	// audio tetap bisa dipulihkan dari salinan terenkripsi
+	backup, err := encrypted.GetBlob(id + backupBlobSuffix)
+	if err != nil || !bytes.Equal(backup.Data, audio) {
+		t.Errorf("backup GetBlob() = %q, %v, want %q", backup.Data, err, audio)
+	}
+
+	// salinan tidak dianggap sebagai audio yang perlu dienkripsi ulang
+	if changed, err := encrypted.Rekey(id + backupBlobSuffix); changed || err != nil {
+		t.Errorf("Rekey(backup) = %v, %v, want false, nil", changed, err)
+	}
+}
@@ -0,0 +1,141 @@
+package data
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// EncryptedReviewStore membungkus ReviewStore agar komentar, override, dan jejak audit review
+// dienkripsi sebelum disimpan. Review memiliki kunci data sendiri karena disimpan di luar dokumen chat
+type EncryptedReviewStore struct {
+	ReviewStore
+	keyring *Keyring
+}
+
+// sealedReview adalah field review yang dienkripsi
+type sealedReview struct {
+	Comments   []ReviewComment `bson:"comments,omitempty"`
+	Overrides  []ScoreOverride `bson:"overrides,omitempty"`
+	ReviewedBy Reviewer        `bson:"reviewedBy"`
+	Events     []ReviewEvent   `bson:"events,omitempty"`
+}
+
+// NewEncryptedReviewStore digunakan untuk membuat penyimpanan review yang mengenkripsi isi review
+func NewEncryptedReviewStore(store ReviewStore, keyring *Keyring) *EncryptedReviewStore {
+	return &EncryptedReviewStore{
+		ReviewStore: store,
+		keyring:     keyring,
+	}
+}
+
+func (e *EncryptedReviewStore) GetReview(chatID string) (Review, error) {
+	review, err := e.ReviewStore.GetReview(chatID)
+	if err != nil {
+		return Review{}, err
+	}
+
+	if err := e.openReview(&review); err != nil {
+		return Review{}, err
+	}
+
+	return review, nil
+}
+
+func (e *EncryptedReviewStore) SaveReview(review Review) error {
+	if err := e.sealReview(&review); err != nil {
+		return err
+	}
+
+	return e.ReviewStore.SaveReview(review)
+}
+
+// Rekey digunakan untuk mengenkripsi ulang kunci data review dengan kunci utama saat ini,
+// review yang belum terenkripsi akan dienkripsi seluruhnya
+func (e *EncryptedReviewStore) Rekey(chatID string) (bool, error) {
+	raw, err := e.ReviewStore.GetReview(chatID)
+	if err != nil {
+		return false, err
+	}
+
+	// review tanpa kunci data dienkripsi melalui SaveReview
+	if raw.DataKey == nil {
+		return true, e.SaveReview(raw)
+	}
+
+	wrapped, changed, err := e.keyring.rewrap(*raw.DataKey)
+	if err != nil || !changed {
+		return false, err
+	}
+
+	// isi review tidak perlu dienkripsi ulang karena kunci datanya tetap sama
+	raw.DataKey = &wrapped
+
+	return true, e.ReviewStore.SaveReview(raw)
+}
+
+// sealReview digunakan untuk mengenkripsi isi review dengan ID chat sebagai konteks enkripsi
+func (e *EncryptedReviewStore) sealReview(review *Review) error {
+	var dek []byte
+	var err error
+
+	if review.DataKey != nil {
+		dek, err = e.keyring.unwrap(*review.DataKey)
+	} else {
+		var wrapped WrappedKey
+		dek, wrapped, err = e.keyring.newDataKey()
+		review.DataKey = &wrapped
+	}
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := bson.Marshal(sealedReview{
+		Comments:   review.Comments,
+		Overrides:  review.Overrides,
+		ReviewedBy: review.ReviewedBy,
+		Events:     review.Events,
+	})
+	if err != nil {
+		return err
+	}
+
+	sealed, err := seal(dek, plaintext, []byte(review.ChatID))
+	if err != nil {
+		return err
+	}
+
+	review.Comments, review.Overrides, review.Events = nil, nil, nil
+	review.ReviewedBy = Reviewer{}
+	review.Sealed = &sealed
+
+	return nil
+}
+
+// openReview digunakan untuk membuka isi review yang dienkripsi
+func (e *EncryptedReviewStore) openReview(review *Review) error {
+	if review.DataKey == nil || review.Sealed == nil {
+		return nil
+	}
+
+	dek, err := e.keyring.unwrap(*review.DataKey)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := open(dek, *review.Sealed, []byte(review.ChatID))
+	if err != nil {
+		return err
+	}
+
+	var content sealedReview
+	if err := bson.Unmarshal(plaintext, &content); err != nil {
+		return err
+	}
+
+	review.Comments = content.Comments
+	review.Overrides = content.Overrides
+	review.ReviewedBy = content.ReviewedBy
+	review.Events = content.Events
+	review.Sealed = nil
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package data
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestEncryptedReviewStore(t *testing.T) {
+	memory := NewMemory()
+	reviews := NewEncryptedReviewStore(memory, newTestKeyring(t))
+
+	review := Review{ChatID: "chat"}
+	review.AddComment(ReviewComment{ID: "c1", Turn: 1, Author: Reviewer{Name: "Rina"}, Text: "strong tradeoff analysis", CreatedAt: time.Now()})
+	if err := reviews.SaveReview(review); err != nil {
+		t.Fatalf("SaveReview() error = %v", err)
+	}
+
+	// review yang tersimpan tidak berisi komentar atau nama reviewer
+	raw, err := memory.GetReview("chat")
+	if err != nil {
+		t.Fatalf("failed to get raw review: %v", err)
+	}
+	encoded, err := bson.Marshal(raw)
+	if err != nil {
+		t.Fatalf("failed to marshal raw review: %v", err)
+	}
+	if raw.Sealed == nil || strings.Contains(string(encoded), "tradeoff") || strings.Contains(string(encoded), "Rina") {
+		t.Errorf("review is stored in plaintext")
+	}
+
+	opened, err := reviews.GetReview("chat")
+	if err != nil {
+		t.Fatalf("GetReview() error = %v", err)
+	}
+	if len(opened.Comments) != 1 || opened.Comments[0].Text != "strong tradeoff analysis" || len(opened.Events) != 1 {
+		t.Errorf("GetReview() = %+v, want the saved comment and event", opened)
+	}
+
+	tests := []struct {
+		name    string
+		version int
+		wantErr error
+	}{
+		{name: "stale version", version: opened.Version - 1, wantErr: ErrConflict},
+		{name: "current version", version: opened.Version},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := *opened.Clone()
+			update.Version = tt.version
+			update.DeleteComment("c1", Reviewer{Name: "Rina"}, time.Now())
+
+			if err := reviews.SaveReview(update); !errors.Is(err, tt.wantErr) {
+				t.Errorf("SaveReview() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// komentar yang dihapus tetap ada di jejak audit
+	latest, err := reviews.GetReview("chat")
+	if err != nil {
+		t.Fatalf("GetReview() error = %v", err)
+	}
+	if len(latest.Comments) != 0 || len(latest.Events) != 2 {
+		t.Errorf("review has %d comments and %d events, want 0 and 2", len(latest.Comments), len(latest.Events))
+	}
+}
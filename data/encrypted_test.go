@@ -0,0 +1,145 @@
+package data
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+)
+
+// insertSearchChats digunakan untuk membuat chat terenkripsi dari yang paling lama,
+// hanya chat dengan indeks di match yang berisi kata "goroutine"
+func insertSearchChats(t *testing.T, client Client, count int, match ...int) {
+	t.Helper()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < count; i++ {
+		content := "I like channels"
+		for _, m := range match {
+			if i == m {
+				content = "I like goroutine"
+			}
+		}
+
+		_, err := client.InsertChat(ChatEntry{
+			ID:        fmt.Sprintf("chat-%04d", i),
+			CreatedAt: start.Add(time.Duration(i) * time.Minute),
+			History:   []Turn{{Role: ai.ROLE_USER, Content: content}},
+		})
+		if err != nil {
+			t.Fatalf("failed to insert chat: %v", err)
+		}
+	}
+}
+
+func TestEncryptedListChatsBoundsScan(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		match     []int
+		limit     int
+		wantPages []int
+	}{
+		{
+			name:      "match beyond the scan limit is found on a later page",
+			count:     maxSearchScan + 50,
+			match:     []int{0},
+			limit:     20,
+			wantPages: []int{0, 1},
+		},
+		{
+			name:      "full page stops the scan early",
+			count:     maxSearchScan + 50,
+			match:     []int{maxSearchScan + 49, maxSearchScan + 48, 0},
+			limit:     2,
+			wantPages: []int{2, 0, 1},
+		},
+		{
+			name:      "no match ends after the last chat",
+			count:     searchBatchSize - 1,
+			limit:     20,
+			wantPages: []int{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewEncrypted(NewMemory(), newTestKeyring(t))
+			insertSearchChats(t, client, tt.count, tt.match...)
+
+			filter := ChatFilter{Query: "goroutine", Limit: tt.limit}
+			var pages []int
+			for {
+				page, err := client.ListChats(filter)
+				if err != nil {
+					t.Fatalf("ListChats() error = %v", err)
+				}
+				if len(page.Chats) > tt.limit {
+					t.Fatalf("ListChats() returned %d chats, want at most %d", len(page.Chats), tt.limit)
+				}
+
+				pages = append(pages, len(page.Chats))
+				if page.Next == nil {
+					break
+				}
+				if len(pages) > tt.count/maxSearchScan+len(tt.match)+1 {
+					t.Fatalf("ListChats() did not finish after %d pages", len(pages))
+				}
+
+				filter.After = page.Next
+			}
+
+			if fmt.Sprint(pages) != fmt.Sprint(tt.wantPages) {
+				t.Errorf("page sizes = %v, want %v", pages, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestEncryptedListChatsWithRetiredKey(t *testing.T) {
+	memory := NewMemory()
+	insertSearchChats(t, NewEncrypted(memory, newTestKeyring(t)), 2, 0, 1)
+
+	// kunci k1 sudah dihapus dari keyring sehingga chat lama tidak bisa dibuka
+	keyring, err := ParseKeyring("k2:" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, dataKeySize)))
+	if err != nil {
+		t.Fatalf("failed to parse keyring: %v", err)
+	}
+	client := NewEncrypted(memory, keyring)
+	if _, err := client.InsertChat(ChatEntry{
+		ID:        "chat-new",
+		CreatedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		History:   []Turn{{Role: ai.ROLE_USER, Content: "I like goroutine"}},
+	}); err != nil {
+		t.Fatalf("failed to insert chat: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		wantIDs []string
+	}{
+		{name: "listing does not open history", wantIDs: []string{"chat-new", "chat-0001", "chat-0000"}},
+		{name: "search skips chats that cannot be opened", query: "goroutine", wantIDs: []string{"chat-new"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := client.ListChats(ChatFilter{Query: tt.query, Limit: 10})
+			if err != nil {
+				t.Fatalf("ListChats() error = %v", err)
+			}
+
+			var ids []string
+			for _, entry := range page.Chats {
+				ids = append(ids, entry.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.wantIDs) {
+				t.Errorf("ListChats() = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
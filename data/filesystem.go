@@ -0,0 +1,200 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// blobIDPattern digunakan untuk memastikan ID aman dipakai sebagai nama file
+var blobIDPattern = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
+
+type FileSystem struct {
+	dir string
+}
+
+type blobMetadata struct {
+	ChatID      string      `json:"chat_id"`
+	ContentType string      `json:"content_type"`
+	CreatedAt   time.Time   `json:"created_at"`
+	Key         *WrappedKey `json:"key,omitempty"`
+}
+
+// NewFileSystem digunakan untuk membuat blob store di direktori lokal
+func NewFileSystem(dir string) (*FileSystem, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &FileSystem{dir: dir}, nil
+}
+
+func (f *FileSystem) PutBlob(blob Blob) (string, error) {
+	if blob.ID == "" {
+		blob.ID = uuid.New().String()
+	}
+	if !blobIDPattern.MatchString(blob.ID) {
+		return "", errors.New("invalid blob ID")
+	}
+	if blob.CreatedAt.IsZero() {
+		blob.CreatedAt = time.Now()
+	}
+
+	meta, err := json.Marshal(blobMetadata{
+		ChatID:      blob.ChatID,
+		ContentType: blob.ContentType,
+		CreatedAt:   blob.CreatedAt,
+		Key:         blob.Key,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(f.dataPath(blob.ID), blob.Data, 0o600); err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(f.metaPath(blob.ID), meta, 0o600); err != nil {
+		return "", err
+	}
+
+	return blob.ID, nil
+}
+
+func (f *FileSystem) GetBlob(id string) (Blob, error) {
+	if !blobIDPattern.MatchString(id) {
+		return Blob{}, ErrBlobNotFound
+	}
+
+	meta, err := f.readMetadata(id)
+	if err != nil {
+		return Blob{}, err
+	}
+
+	content, err := os.ReadFile(f.dataPath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return Blob{}, ErrBlobNotFound
+	}
+	if err != nil {
+		return Blob{}, err
+	}
+
+	return Blob{
+		ID:          id,
+		ChatID:      meta.ChatID,
+		ContentType: meta.ContentType,
+		Data:        content,
+		CreatedAt:   meta.CreatedAt,
+		Key:         meta.Key,
+	}, nil
+}
+
+func (f *FileSystem) DeleteBlob(id string) error {
+	if !blobIDPattern.MatchString(id) {
+		return ErrBlobNotFound
+	}
+
+	if err := os.Remove(f.dataPath(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := os.Remove(f.metaPath(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (f *FileSystem) DeleteChatBlobs(chatID string) error {
+	metaFiles, err := filepath.Glob(filepath.Join(f.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, metaFile := range metaFiles {
+		metaByte, err := os.ReadFile(metaFile)
+		if err != nil {
+			return err
+		}
+
+		var meta blobMetadata
+		if err := json.Unmarshal(metaByte, &meta); err != nil {
+			return err
+		}
+
+		if meta.ChatID != chatID {
+			continue
+		}
+
+		if err := f.DeleteBlob(strings.TrimSuffix(filepath.Base(metaFile), ".json")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *FileSystem) SetBlobKey(id string, key WrappedKey) error {
+	if !blobIDPattern.MatchString(id) {
+		return ErrBlobNotFound
+	}
+
+	meta, err := f.readMetadata(id)
+	if err != nil {
+		return err
+	}
+	meta.Key = &key
+
+	metaByte, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(f.metaPath(id), metaByte, 0o600)
+}
+
+// BlobIDs digunakan untuk mendapatkan ID semua blob yang tersimpan
+func (f *FileSystem) BlobIDs() ([]string, error) {
+	metaFiles, err := filepath.Glob(filepath.Join(f.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(metaFiles))
+	for _, metaFile := range metaFiles {
+		ids = append(ids, strings.TrimSuffix(filepath.Base(metaFile), ".json"))
+	}
+
+	return ids, nil
+}
+
+func (f *FileSystem) readMetadata(id string) (blobMetadata, error) {
+	metaByte, err := os.ReadFile(f.metaPath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return blobMetadata{}, ErrBlobNotFound
+	}
+	if err != nil {
+		return blobMetadata{}, err
+	}
+
+	var meta blobMetadata
+	if err := json.Unmarshal(metaByte, &meta); err != nil {
+		return blobMetadata{}, err
+	}
+
+	return meta, nil
+}
+
+func (f *FileSystem) dataPath(id string) string {
+	return filepath.Join(f.dir, id)
+}
+
+func (f *FileSystem) metaPath(id string) string {
+	return filepath.Join(f.dir, id+".json")
+}
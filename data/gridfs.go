@@ -0,0 +1,158 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const bucket = "audio"
+
+type GridFS struct {
+	bucket *gridfs.Bucket
+}
+
+type gridFSMetadata struct {
+	ChatID      string      `bson:"chatId"`
+	ContentType string      `bson:"contentType"`
+	Key         *WrappedKey `bson:"key,omitempty"`
+}
+
+// NewGridFS digunakan untuk membuat blob store di GridFS pada database Mongo
+func NewGridFS(m *Mongo) (*GridFS, error) {
+	b, err := gridfs.NewBucket(m.db, options.GridFSBucket().SetName(bucket))
+	if err != nil {
+		return nil, err
+	}
+
+	return &GridFS{bucket: b}, nil
+}
+
+func (g *GridFS) PutBlob(blob Blob) (string, error) {
+	if blob.ID == "" {
+		blob.ID = uuid.New().String()
+	}
+
+	opts := options.GridFSUpload().SetMetadata(gridFSMetadata{
+		ChatID:      blob.ChatID,
+		ContentType: blob.ContentType,
+		Key:         blob.Key,
+	})
+
+	err := g.bucket.UploadFromStreamWithID(blob.ID, blob.ID, bytes.NewReader(blob.Data), opts)
+	if err != nil {
+		return "", err
+	}
+
+	return blob.ID, nil
+}
+
+func (g *GridFS) GetBlob(id string) (Blob, error) {
+	stream, err := g.bucket.OpenDownloadStream(id)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return Blob{}, ErrBlobNotFound
+	}
+	if err != nil {
+		return Blob{}, err
+	}
+	defer stream.Close()
+
+	content, err := io.ReadAll(stream)
+	if err != nil {
+		return Blob{}, err
+	}
+
+	file := stream.GetFile()
+
+	var meta gridFSMetadata
+	if len(file.Metadata) > 0 {
+		if err := bson.Unmarshal(file.Metadata, &meta); err != nil {
+			return Blob{}, err
+		}
+	}
+
+	return Blob{
+		ID:          id,
+		ChatID:      meta.ChatID,
+		ContentType: meta.ContentType,
+		Data:        content,
+		CreatedAt:   file.UploadDate,
+		Key:         meta.Key,
+	}, nil
+}
+
+func (g *GridFS) DeleteBlob(id string) error {
+	err := g.bucket.Delete(id)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return ErrBlobNotFound
+	}
+
+	return err
+}
+
+func (g *GridFS) DeleteChatBlobs(chatID string) error {
+	cursor, err := g.bucket.Find(bson.M{"metadata.chatId": chatID})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.Background())
+
+	for cursor.Next(context.Background()) {
+		var file struct {
+			ID string `bson:"_id"`
+		}
+		if err := cursor.Decode(&file); err != nil {
+			return err
+		}
+
+		err := g.bucket.Delete(file.ID)
+		if err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+func (g *GridFS) SetBlobKey(id string, key WrappedKey) error {
+	update := bson.M{"$set": bson.M{"metadata.key": key}}
+
+	result, err := g.bucket.GetFilesCollection().UpdateOne(context.Background(), bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrBlobNotFound
+	}
+
+	return nil
+}
+
+// BlobIDs digunakan untuk mendapatkan ID semua blob yang tersimpan
+func (g *GridFS) BlobIDs() ([]string, error) {
+	cursor, err := g.bucket.Find(bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var ids []string
+	for cursor.Next(context.Background()) {
+		var file struct {
+			ID string `bson:"_id"`
+		}
+		if err := cursor.Decode(&file); err != nil {
+			return ids, err
+		}
+		ids = append(ids, file.ID)
+	}
+
+	return ids, cursor.Err()
+}
@@ -0,0 +1,200 @@
+package data
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+	"github.com/google/uuid"
+)
+
+// Memory menyimpan chat di memori, cocok untuk pengembangan lokal tanpa MongoDB
+type Memory struct {
+	mu            sync.Mutex
+	chats         map[string]ChatEntry
+	accounts      map[string]Account
+	shareAccesses map[string][]ShareAccess
+	reviews       map[string]Review
+}
+
+// NewMemory digunakan untuk membuat penyimpanan chat di memori
+func NewMemory() *Memory {
+	return &Memory{
+		chats:         make(map[string]ChatEntry),
+		accounts:      make(map[string]Account),
+		shareAccesses: make(map[string][]ShareAccess),
+		reviews:       make(map[string]Review),
+	}
+}
+
+func (m *Memory) InsertChat(data ChatEntry) (string, error) {
+	if data.ID == "" {
+		data.ID = uuid.New().String()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.chats[data.ID] = copyChat(data)
+
+	return data.ID, nil
+}
+
+func (m *Memory) GetChat(id string) (ChatEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, ok := m.chats[id]
+	if !ok {
+		return ChatEntry{}, ErrChatNotFound
+	}
+
+	return copyChat(data), nil
+}
+
+func (m *Memory) UpdateChat(id string, data ChatEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, ok := m.chats[id]
+	if !ok || current.Version != data.Version {
+		return ErrConflict
+	}
+
+	data.ID = id
+	data.Version++
+	m.chats[id] = copyChat(data)
+
+	return nil
+}
+
+func (m *Memory) DeleteChat(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.chats, id)
+
+	return nil
+}
+
+func (m *Memory) ExpireRecordings(before time.Time) ([]Recording, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expired []Recording
+	for id, data := range m.chats {
+		data = copyChat(data)
+
+		removed := data.ExpireRecordings(before)
+		if len(removed) == 0 {
+			continue
+		}
+
+		data.Version++
+		m.chats[id] = data
+		expired = append(expired, removed...)
+	}
+
+	return expired, nil
+}
+
+func (m *Memory) ExpireChats(before time.Time) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var ids []string
+	for id, data := range m.chats {
+		if data.ExpiresAt.IsZero() || data.ExpiresAt.After(before) {
+			continue
+		}
+
+		delete(m.chats, id)
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (m *Memory) ListChats(f ChatFilter) (ChatPage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var chats []ChatEntry
+	for _, data := range m.chats {
+		if !f.Match(data) || !data.Contains(f.Query) {
+			continue
+		}
+		if f.After != nil && !f.After.before(data) {
+			continue
+		}
+
+		chats = append(chats, copyChat(data))
+	}
+
+	// urutkan dari yang paling baru seperti index createdat di MongoDB
+	sort.Slice(chats, func(i, j int) bool {
+		return ChatCursor{CreatedAt: chats[i].CreatedAt, ID: chats[i].ID}.before(chats[j])
+	})
+
+	if f.Limit > 0 && len(chats) > f.Limit {
+		chats = chats[:f.Limit]
+	}
+
+	return pageOf(chats, f.Limit), nil
+}
+
+func (m *Memory) ChatStats(from, to time.Time) ([]TemplateStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	filter := ChatFilter{From: from, To: to}
+	byTemplate := make(map[string]*TemplateStats)
+	answers := make(map[string]int)
+	totalScore := make(map[string]float64)
+
+	for _, data := range m.chats {
+		if !filter.Match(data) {
+			continue
+		}
+
+		stat, ok := byTemplate[data.Template]
+		if !ok {
+			stat = &TemplateStats{Template: data.Template}
+			byTemplate[data.Template] = stat
+		}
+
+		stat.Chats++
+		if data.Progress.Finished {
+			stat.Finished++
+		}
+		if data.Scorecard != nil {
+			stat.Scored++
+			totalScore[data.Template] += data.Scorecard.Overall
+		}
+		answers[data.Template] += data.AnswerCount()
+	}
+
+	stats := make([]TemplateStats, 0, len(byTemplate))
+	for template, stat := range byTemplate {
+		stat.AverageAnswers = float64(answers[template]) / float64(stat.Chats)
+		if stat.Scored > 0 {
+			stat.AverageScore = totalScore[template] / float64(stat.Scored)
+		}
+		stats = append(stats, *stat)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Template < stats[j].Template
+	})
+
+	return stats, nil
+}
+
+// copyChat digunakan agar history yang disimpan tidak ikut berubah oleh pemanggil
+func copyChat(data ChatEntry) ChatEntry {
+	data.History = append([]Turn(nil), data.History...)
+	data.Questions = append(interview.Questions(nil), data.Questions...)
+	data.Shares = append([]Share(nil), data.Shares...)
+	return data
+}
@@ -0,0 +1,380 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrReviewNotFound dikembalikan ketika chat belum pernah direview
+var ErrReviewNotFound = errors.New("review not found")
+
+// reviewCollection adalah collection MongoDB untuk review chat
+const reviewCollection = "review"
+
+// ReviewAction adalah jenis perubahan review yang dicatat di jejak audit
+type ReviewAction string
+
+const (
+	REVIEW_COMMENT_ADDED    ReviewAction = "comment_added"
+	REVIEW_COMMENT_DELETED  ReviewAction = "comment_deleted"
+	REVIEW_SCORE_OVERRIDDEN ReviewAction = "score_overridden"
+	REVIEW_OVERRIDE_REMOVED ReviewAction = "override_removed"
+	REVIEW_MARKED_REVIEWED  ReviewAction = "marked_reviewed"
+	REVIEW_MARKED_PENDING   ReviewAction = "marked_pending"
+	REVIEW_PUBLISHED        ReviewAction = "published"
+	REVIEW_UNPUBLISHED      ReviewAction = "unpublished"
+)
+
+// Reviewer adalah pengguna yang mengubah review
+type Reviewer struct {
+	// ID kosong untuk admin dari konfigurasi yang tidak memiliki akun
+	ID   string `bson:",omitempty"`
+	Name string
+}
+
+// Review berisi penilaian manusia atas chat, disimpan terpisah dari History
+// sehingga penilaian AI tetap utuh dan bisa dibandingkan
+type Review struct {
+	// ChatID adalah ID chat yang direview, review ikut dihapus ketika chat dihapus
+	ChatID string `bson:"_id"`
+	// Version dinaikkan setiap kali review disimpan untuk optimistic locking
+	Version int
+
+	Comments  []ReviewComment `bson:",omitempty"`
+	Overrides []ScoreOverride `bson:",omitempty"`
+
+	// ReviewedAt kosong berarti chat belum selesai direview
+	ReviewedAt time.Time `bson:",omitempty"`
+	ReviewedBy Reviewer
+
+	// PublishedAt kosong berarti review hanya bisa dilihat reviewer, bukan kandidat
+	PublishedAt time.Time `bson:",omitempty"`
+
+	// Events adalah jejak audit semua perubahan review, tidak pernah diubah atau dihapus
+	Events []ReviewEvent `bson:",omitempty"`
+
+	// DataKey adalah kunci untuk membuka isi review yang dienkripsi di Sealed
+	DataKey *WrappedKey `bson:",omitempty"`
+	Sealed  *Sealed     `bson:",omitempty"`
+}
+
+// ReviewComment adalah komentar reviewer pada satu jawaban
+type ReviewComment struct {
+	ID string
+	// Turn adalah nomor jawaban, sama dengan turn di scorecard
+	Turn      int
+	Author    Reviewer
+	Text      string
+	CreatedAt time.Time
+}
+
+// ScoreOverride menggantikan nilai AI satu kemampuan dengan nilai dari reviewer
+type ScoreOverride struct {
+	Competency    string
+	Level         int
+	Justification string
+	Author        Reviewer
+	At            time.Time
+}
+
+// ReviewEvent adalah satu perubahan review di jejak audit
+type ReviewEvent struct {
+	Action ReviewAction
+	Actor  Reviewer
+	At     time.Time
+
+	Turn       int    `bson:",omitempty"`
+	CommentID  string `bson:",omitempty"`
+	Competency string `bson:",omitempty"`
+	Level      int    `bson:",omitempty"`
+	// PreviousLevel adalah nilai override sebelumnya, 0 berarti sebelumnya memakai nilai AI
+	PreviousLevel int `bson:",omitempty"`
+	// Text berisi isi komentar atau alasan override saat perubahan dilakukan
+	Text string `bson:",omitempty"`
+}
+
+// Clone digunakan untuk menyalin review agar perubahan tidak ikut mengubah review yang disimpan
+func (r *Review) Clone() *Review {
+	if r == nil {
+		return nil
+	}
+
+	clone := *r
+	clone.Comments = slices.Clone(r.Comments)
+	clone.Overrides = slices.Clone(r.Overrides)
+	clone.Events = slices.Clone(r.Events)
+
+	return &clone
+}
+
+// Reviewed digunakan untuk mengecek apakah chat sudah selesai direview
+func (r *Review) Reviewed() bool {
+	return r != nil && !r.ReviewedAt.IsZero()
+}
+
+// Published digunakan untuk mengecek apakah review sudah bisa dilihat kandidat
+func (r *Review) Published() bool {
+	return r != nil && !r.PublishedAt.IsZero()
+}
+
+// GetOverride digunakan untuk mengambil override nilai satu kemampuan
+func (r *Review) GetOverride(competency string) (ScoreOverride, bool) {
+	if r == nil {
+		return ScoreOverride{}, false
+	}
+
+	for _, override := range r.Overrides {
+		if override.Competency == competency {
+			return override, true
+		}
+	}
+
+	return ScoreOverride{}, false
+}
+
+// OverrideLevels digunakan untuk mendapatkan nilai override berdasarkan ID kemampuan
+func (r *Review) OverrideLevels() map[string]int {
+	levels := make(map[string]int)
+	if r == nil {
+		return levels
+	}
+
+	for _, override := range r.Overrides {
+		levels[override.Competency] = override.Level
+	}
+
+	return levels
+}
+
+// AddComment digunakan untuk menambahkan komentar dan mencatatnya di jejak audit
+func (r *Review) AddComment(comment ReviewComment) {
+	r.Comments = append(r.Comments, comment)
+	r.Events = append(r.Events, ReviewEvent{
+		Action:    REVIEW_COMMENT_ADDED,
+		Actor:     comment.Author,
+		At:        comment.CreatedAt,
+		Turn:      comment.Turn,
+		CommentID: comment.ID,
+		Text:      comment.Text,
+	})
+}
+
+// DeleteComment digunakan untuk menghapus komentar, isi komentar tetap tersimpan di jejak audit
+func (r *Review) DeleteComment(id string, actor Reviewer, now time.Time) bool {
+	index := slices.IndexFunc(r.Comments, func(c ReviewComment) bool { return c.ID == id })
+	if index < 0 {
+		return false
+	}
+
+	comment := r.Comments[index]
+	r.Comments = slices.Delete(r.Comments, index, index+1)
+	r.Events = append(r.Events, ReviewEvent{
+		Action:    REVIEW_COMMENT_DELETED,
+		Actor:     actor,
+		At:        now,
+		Turn:      comment.Turn,
+		CommentID: comment.ID,
+		Text:      comment.Text,
+	})
+
+	return true
+}
+
+// Override digunakan untuk mengganti nilai satu kemampuan beserta alasannya
+func (r *Review) Override(override ScoreOverride) {
+	event := ReviewEvent{
+		Action:     REVIEW_SCORE_OVERRIDDEN,
+		Actor:      override.Author,
+		At:         override.At,
+		Competency: override.Competency,
+		Level:      override.Level,
+		Text:       override.Justification,
+	}
+
+	index := slices.IndexFunc(r.Overrides, func(o ScoreOverride) bool { return o.Competency == override.Competency })
+	if index < 0 {
+		r.Overrides = append(r.Overrides, override)
+	} else {
+		event.PreviousLevel = r.Overrides[index].Level
+		r.Overrides[index] = override
+	}
+
+	r.Events = append(r.Events, event)
+}
+
+// RemoveOverride digunakan untuk mengembalikan nilai satu kemampuan ke nilai AI
+func (r *Review) RemoveOverride(competency string, actor Reviewer, now time.Time) bool {
+	index := slices.IndexFunc(r.Overrides, func(o ScoreOverride) bool { return o.Competency == competency })
+	if index < 0 {
+		return false
+	}
+
+	previous := r.Overrides[index]
+	r.Overrides = slices.Delete(r.Overrides, index, index+1)
+	r.Events = append(r.Events, ReviewEvent{
+		Action:        REVIEW_OVERRIDE_REMOVED,
+		Actor:         actor,
+		At:            now,
+		Competency:    competency,
+		PreviousLevel: previous.Level,
+	})
+
+	return true
+}
+
+// SetReviewed digunakan untuk menandai chat selesai atau belum selesai direview,
+// review yang kembali belum selesai juga ditarik dari kandidat
+func (r *Review) SetReviewed(reviewed bool, actor Reviewer, now time.Time) {
+	if reviewed == r.Reviewed() {
+		return
+	}
+
+	if !reviewed {
+		r.SetPublished(false, actor, now)
+
+		r.ReviewedAt = time.Time{}
+		r.ReviewedBy = Reviewer{}
+		r.Events = append(r.Events, ReviewEvent{Action: REVIEW_MARKED_PENDING, Actor: actor, At: now})
+
+		return
+	}
+
+	r.ReviewedAt = now
+	r.ReviewedBy = actor
+	r.Events = append(r.Events, ReviewEvent{Action: REVIEW_MARKED_REVIEWED, Actor: actor, At: now})
+}
+
+// SetPublished digunakan untuk menampilkan atau menarik review dari kandidat
+func (r *Review) SetPublished(published bool, actor Reviewer, now time.Time) {
+	if published == r.Published() {
+		return
+	}
+
+	action := REVIEW_UNPUBLISHED
+	r.PublishedAt = time.Time{}
+	if published {
+		action = REVIEW_PUBLISHED
+		r.PublishedAt = now
+	}
+
+	r.Events = append(r.Events, ReviewEvent{Action: action, Actor: actor, At: now})
+}
+
+// ReviewStore digunakan untuk menyimpan review di luar dokumen chat sehingga perubahan reviewer
+// tidak bersaing dengan jawaban kandidat. Review harus dihapus bersama chatnya
+type ReviewStore interface {
+	GetReview(chatID string) (Review, error)
+	// SaveReview digunakan untuk menyimpan review dengan optimistic locking, review baru memiliki Version 0.
+	// ErrConflict dikembalikan jika review sudah diubah request lain
+	SaveReview(Review) error
+	// DeleteReview digunakan untuk menghapus review beserta jejak auditnya, chat yang belum direview tidak dianggap error
+	DeleteReview(chatID string) error
+}
+
+func (m *Memory) GetReview(chatID string) (Review, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	review, ok := m.reviews[chatID]
+	if !ok {
+		return Review{}, ErrReviewNotFound
+	}
+
+	return *review.Clone(), nil
+}
+
+func (m *Memory) SaveReview(review Review) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.reviews[review.ChatID].Version != review.Version {
+		return ErrConflict
+	}
+
+	review.Version++
+	m.reviews[review.ChatID] = *review.Clone()
+
+	return nil
+}
+
+func (m *Memory) DeleteReview(chatID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.reviews, chatID)
+
+	return nil
+}
+
+func (m *Mongo) GetReview(chatID string) (Review, error) {
+	var review Review
+
+	err := m.db.Collection(reviewCollection).FindOne(context.Background(), bson.M{"_id": chatID}).Decode(&review)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Review{}, ErrReviewNotFound
+	}
+
+	return review, err
+}
+
+func (m *Mongo) SaveReview(review Review) error {
+	reviews := m.db.Collection(reviewCollection)
+	filter := bson.M{"_id": review.ChatID, "version": review.Version}
+	review.Version++
+
+	// review pertama ditambahkan, ID yang sudah ada berarti reviewer lain menyimpan lebih dulu
+	if review.Version == 1 {
+		_, err := reviews.InsertOne(context.Background(), review)
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrConflict
+		}
+
+		return err
+	}
+
+	result, err := reviews.ReplaceOne(context.Background(), filter, review)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrConflict
+	}
+
+	return nil
+}
+
+func (m *Mongo) DeleteReview(chatID string) error {
+	_, err := m.db.Collection(reviewCollection).DeleteOne(context.Background(), bson.M{"_id": chatID})
+	return err
+}
+
+// ReviewChatIDs digunakan untuk mengambil ID chat dari semua review
+func (m *Mongo) ReviewChatIDs() ([]string, error) {
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+
+	cursor, err := m.db.Collection(reviewCollection).Find(context.Background(), bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var ids []string
+	for cursor.Next(context.Background()) {
+		var data struct {
+			ID string `bson:"_id"`
+		}
+		if err := cursor.Decode(&data); err != nil {
+			return ids, err
+		}
+		ids = append(ids, data.ID)
+	}
+
+	return ids, cursor.Err()
+}
@@ -0,0 +1,170 @@
+package data
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+)
+
+// ErrInvalidCursor dikembalikan ketika cursor halaman tidak bisa dibaca
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ChatFilter berisi filter daftar chat untuk admin, field kosong berarti tidak difilter.
+// Chat diurutkan dari yang paling baru dibuat
+type ChatFilter struct {
+	Template string
+	// From dan To membatasi waktu chat dibuat, From inklusif dan To eksklusif
+	From time.Time
+	To   time.Time
+	// MinScore dan MaxScore membatasi nilai scorecard (0 sampai 1), chat yang belum dinilai tidak ikut
+	MinScore *float64
+	MaxScore *float64
+	Finished *bool
+	// Query dicari di isi history, setiap kata harus muncul tanpa membedakan huruf besar dan kecil
+	Query string
+
+	// After adalah chat terakhir di halaman sebelumnya
+	After *ChatCursor
+	Limit int
+}
+
+// ChatPage berisi satu halaman daftar chat admin
+type ChatPage struct {
+	Chats []ChatEntry
+	// Next adalah cursor halaman berikutnya, nil berarti tidak ada chat lagi
+	Next *ChatCursor
+}
+
+// pageOf digunakan untuk membuat halaman dari hasil query,
+// halaman penuh berarti mungkin masih ada chat berikutnya
+func pageOf(chats []ChatEntry, limit int) ChatPage {
+	page := ChatPage{Chats: chats}
+	if limit > 0 && len(chats) == limit {
+		next := CursorOf(chats[len(chats)-1])
+		page.Next = &next
+	}
+
+	return page
+}
+
+// ChatCursor menandai posisi chat di daftar yang diurutkan berdasarkan waktu dibuat dan ID
+type ChatCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// CursorOf digunakan untuk membuat cursor halaman berikutnya dari chat terakhir
+func CursorOf(entry ChatEntry) ChatCursor {
+	return ChatCursor{CreatedAt: entry.CreatedAt, ID: entry.ID}
+}
+
+// Encode digunakan untuk mengubah cursor menjadi string yang aman dipakai di query URL
+func (c ChatCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + ":" + c.ID))
+}
+
+// ParseCursor digunakan untuk membaca cursor dari query URL
+func ParseCursor(s string) (ChatCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return ChatCursor{}, ErrInvalidCursor
+	}
+
+	nanos, id, ok := strings.Cut(string(decoded), ":")
+	if !ok || id == "" {
+		return ChatCursor{}, ErrInvalidCursor
+	}
+
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return ChatCursor{}, ErrInvalidCursor
+	}
+
+	return ChatCursor{CreatedAt: time.Unix(0, n), ID: id}, nil
+}
+
+// before digunakan untuk mengecek apakah chat berada setelah cursor di urutan daftar
+func (c ChatCursor) before(entry ChatEntry) bool {
+	if !entry.CreatedAt.Equal(c.CreatedAt) {
+		return entry.CreatedAt.Before(c.CreatedAt)
+	}
+
+	return entry.ID < c.ID
+}
+
+// Match digunakan untuk mengecek filter selain Query dan cursor pada chat
+func (f ChatFilter) Match(entry ChatEntry) bool {
+	if f.Template != "" && entry.Template != f.Template {
+		return false
+	}
+	if !f.From.IsZero() && entry.CreatedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !entry.CreatedAt.Before(f.To) {
+		return false
+	}
+	if f.Finished != nil && entry.Progress.Finished != *f.Finished {
+		return false
+	}
+
+	if f.MinScore != nil || f.MaxScore != nil {
+		if entry.Scorecard == nil {
+			return false
+		}
+		if f.MinScore != nil && entry.Scorecard.Overall < *f.MinScore {
+			return false
+		}
+		if f.MaxScore != nil && entry.Scorecard.Overall > *f.MaxScore {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SearchTerms digunakan untuk memecah query pencarian menjadi kata huruf kecil
+func SearchTerms(query string) []string {
+	return strings.Fields(strings.ToLower(query))
+}
+
+// Contains digunakan untuk mencari semua kata query di isi history selain pesan system,
+// isi yang masih terenkripsi tidak ikut dicari
+func (e ChatEntry) Contains(query string) bool {
+	terms := SearchTerms(query)
+	if len(terms) == 0 {
+		return true
+	}
+
+	var b strings.Builder
+	for _, t := range e.History {
+		if t.Role == ai.ROLE_SYSTEM {
+			continue
+		}
+		b.WriteString(strings.ToLower(t.Content))
+		b.WriteByte('\n')
+	}
+	text := b.String()
+
+	for _, term := range terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TemplateStats berisi ringkasan chat untuk satu template
+type TemplateStats struct {
+	Template string
+	Chats    int
+	Finished int
+	// Scored adalah jumlah chat yang memiliki scorecard, AverageScore hanya dihitung dari chat tersebut
+	Scored         int
+	AverageScore   float64
+	AverageAnswers float64
+}
@@ -0,0 +1,166 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MAX_SHARE_ACCESSES adalah jumlah akses link share terakhir yang disimpan per chat
+const MAX_SHARE_ACCESSES = 200
+
+// shareAccessCollection adalah collection MongoDB untuk akses link share
+const shareAccessCollection = "share_access"
+
+// Share menyimpan link baca saja yang dibuat pemilik chat,
+// token link ditandatangani dari ID dan ExpiresAt sehingga tidak perlu disimpan
+type Share struct {
+	ID        string
+	Label     string `bson:",omitempty"`
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	// RevokedAt kosong berarti link belum dicabut
+	RevokedAt time.Time `bson:",omitempty"`
+}
+
+// Active digunakan untuk mengecek apakah link share masih bisa dipakai
+func (s Share) Active(now time.Time) bool {
+	return s.RevokedAt.IsZero() && now.Before(s.ExpiresAt)
+}
+
+// ShareAccess menyimpan satu akses ke chat melalui link share
+type ShareAccess struct {
+	ShareID   string
+	Resource  string
+	IP        string `bson:",omitempty"`
+	UserAgent string `bson:",omitempty"`
+	At        time.Time
+}
+
+// GetShare digunakan untuk mengambil posisi link share berdasarkan ID
+func (e ChatEntry) GetShare(id string) (int, bool) {
+	for i, s := range e.Shares {
+		if s.ID == id {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// ShareAccessStore digunakan untuk mencatat akses link share di luar dokumen chat,
+// pencatatan tidak memakai versi chat sehingga tidak bersaing dengan update chat
+type ShareAccessStore interface {
+	LogShareAccess(chatID string, access ShareAccess) error
+	// ListShareAccesses digunakan untuk mengambil MAX_SHARE_ACCESSES akses terakhir chat, dari yang paling lama
+	ListShareAccesses(chatID string) ([]ShareAccess, error)
+	DeleteShareAccesses(chatID string) error
+}
+
+func (m *Memory) LogShareAccess(chatID string, access ShareAccess) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	accesses := append(m.shareAccesses[chatID], access)
+	if len(accesses) > MAX_SHARE_ACCESSES {
+		accesses = slices.Clone(accesses[len(accesses)-MAX_SHARE_ACCESSES:])
+	}
+	m.shareAccesses[chatID] = accesses
+
+	return nil
+}
+
+func (m *Memory) ListShareAccesses(chatID string) ([]ShareAccess, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.shareAccesses[chatID]), nil
+}
+
+func (m *Memory) DeleteShareAccesses(chatID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.shareAccesses, chatID)
+
+	return nil
+}
+
+// shareAccessDocument adalah dokumen akses link share di MongoDB,
+// ObjectID dipakai untuk mengurutkan akses sesuai urutan dicatat
+type shareAccessDocument struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	ChatID      string
+	ShareAccess `bson:",inline"`
+}
+
+// ensureShareAccessIndexes digunakan untuk membuat index akses link share per chat
+func (m *Mongo) ensureShareAccessIndexes() error {
+	chat := mongo.IndexModel{
+		Keys: bson.D{{Key: "chatid", Value: 1}, {Key: "_id", Value: -1}},
+	}
+
+	_, err := m.db.Collection(shareAccessCollection).Indexes().CreateOne(context.Background(), chat)
+	return err
+}
+
+func (m *Mongo) LogShareAccess(chatID string, access ShareAccess) error {
+	accesses := m.db.Collection(shareAccessCollection)
+
+	_, err := accesses.InsertOne(context.Background(), shareAccessDocument{ChatID: chatID, ShareAccess: access})
+	if err != nil {
+		return err
+	}
+
+	// hapus akses lama agar jumlah akses per chat tetap terbatas
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetSkip(MAX_SHARE_ACCESSES).
+		SetProjection(bson.M{"_id": 1})
+
+	var oldest shareAccessDocument
+	err = accesses.FindOne(context.Background(), bson.M{"chatid": chatID}, opts).Decode(&oldest)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = accesses.DeleteMany(context.Background(), bson.M{"chatid": chatID, "_id": bson.M{"$lte": oldest.ID}})
+	return err
+}
+
+func (m *Mongo) ListShareAccesses(chatID string) ([]ShareAccess, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(MAX_SHARE_ACCESSES)
+
+	cursor, err := m.db.Collection(shareAccessCollection).Find(context.Background(), bson.M{"chatid": chatID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []shareAccessDocument
+	if err := cursor.All(context.Background(), &documents); err != nil {
+		return nil, err
+	}
+
+	accesses := make([]ShareAccess, 0, len(documents))
+	for i := len(documents) - 1; i >= 0; i-- {
+		accesses = append(accesses, documents[i].ShareAccess)
+	}
+
+	return accesses, nil
+}
+
+func (m *Mongo) DeleteShareAccesses(chatID string) error {
+	_, err := m.db.Collection(shareAccessCollection).DeleteMany(context.Background(), bson.M{"chatid": chatID})
+	return err
+}
@@ -0,0 +1,133 @@
+package data
+
+import (
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+	"github.com/fastcampus-backend-golang/ai-interview/design"
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+	"github.com/fastcampus-backend-golang/ai-interview/sandbox"
+	"github.com/fastcampus-backend-golang/ai-interview/speech"
+)
+
+// Turn menyimpan satu pesan di history beserta metadatanya,
+// field Role dan Content sama dengan ai.ChatMessage sehingga history lama tetap terbaca
+type Turn struct {
+	Role      ai.Role
+	Content   string
+	CreatedAt time.Time `bson:",omitempty"`
+	// Stage adalah tahap interview saat giliran ini terjadi
+	Stage interview.Stage `bson:",omitempty"`
+	// QuestionID adalah pertanyaan bank soal yang diajukan atau dijawab di giliran ini
+	QuestionID string `bson:",omitempty"`
+	// Speaker adalah persona interviewer yang berbicara di giliran ini pada mode panel
+	Speaker string `bson:",omitempty"`
+	// Decision adalah keputusan policy yang diberikan ke AI untuk giliran ini
+	Decision interview.Decision `bson:",omitempty"`
+
+	// Model adalah model yang menghasilkan pesan ini (transkripsi atau chat)
+	Model   string    `bson:",omitempty"`
+	Usage   *ai.Usage `bson:",omitempty"`
+	Latency Latency
+
+	// Transcript adalah hasil transkripsi mentah dari jawaban user sebelum disamarkan dan dicek guard,
+	// Content berisi teks yang sudah dibersihkan dan dikirim ke AI
+	Transcript string `bson:",omitempty"`
+	// SpeechText adalah teks yang sudah dibersihkan untuk TTS
+	SpeechText string `bson:",omitempty"`
+
+	// Flags berisi alasan jawaban ditandai oleh guard
+	Flags []Flag `bson:",omitempty"`
+
+	Audio     *AudioRef  `bson:",omitempty"`
+	Recording *Recording `bson:",omitempty"`
+
+	// Submission berisi kode yang dikirim user beserta hasil test tersembunyi
+	Submission *Submission `bson:",omitempty"`
+	// Design berisi desain sistem yang dikirim user
+	Design *design.Design `bson:",omitempty"`
+
+	// Speech berisi analisis cara user berbicara di jawaban lisan
+	Speech *speech.Metrics `bson:",omitempty"`
+
+	// STAR berisi analisis Situation, Task, Action, dan Result dari jawaban behavioral
+	STAR *interview.STAR `bson:",omitempty"`
+
+	// Ideal berisi contoh jawaban yang baik untuk jawaban ini, disimpan setelah pertama kali dibuat
+	Ideal *interview.IdealAnswer `bson:",omitempty"`
+
+	// Scores berisi nilai sementara jawaban user berdasarkan rubric template
+	Scores []interview.Score `bson:",omitempty"`
+
+	// Sealed berisi Content, Transcript, SpeechText, Scores, STAR, dan Ideal yang dienkripsi
+	Sealed *Sealed `bson:",omitempty"`
+}
+
+// Latency menyimpan lama setiap tahap pemrosesan giliran
+type Latency struct {
+	Transcription time.Duration `bson:",omitempty"`
+	Completion    time.Duration `bson:",omitempty"`
+	Speech        time.Duration `bson:",omitempty"`
+}
+
+// Flag menyimpan hasil moderasi atau deteksi prompt injection pada jawaban
+type Flag struct {
+	Kind   string
+	Detail string
+	Action string
+}
+
+// Submission menyimpan kode jawaban soal coding dan hasil menjalankannya
+type Submission struct {
+	QuestionID string
+	Code       string `bson:",omitempty"`
+	Status     sandbox.Status
+	Tests      []sandbox.TestResult
+	Output     string `bson:",omitempty"`
+	Duration   time.Duration
+}
+
+// AudioRef menyimpan referensi audio hasil TTS di blob store
+type AudioRef struct {
+	BlobID      string
+	ContentType string
+	Provider    string
+}
+
+// NewTurn digunakan untuk membuat giliran dari ai.ChatMessage
+func NewTurn(message ai.ChatMessage) Turn {
+	return Turn{
+		Role:      message.Role,
+		Content:   message.Content,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Message digunakan untuk mengubah giliran menjadi ai.ChatMessage
+func (t Turn) Message() ai.ChatMessage {
+	return ai.ChatMessage{
+		Role:    t.Role,
+		Content: t.Content,
+		Name:    t.Speaker,
+	}
+}
+
+// Messages digunakan untuk mengubah history menjadi pesan yang dikirim ke AI
+func Messages(turns []Turn) []ai.ChatMessage {
+	messages := make([]ai.ChatMessage, 0, len(turns))
+	for _, t := range turns {
+		messages = append(messages, t.Message())
+	}
+
+	return messages
+}
+
+// NewTurns digunakan untuk mengubah daftar ai.ChatMessage menjadi history
+func NewTurns(messages []ai.ChatMessage) []Turn {
+	turns := make([]Turn, 0, len(messages))
+	for _, message := range messages {
+		turns = append(turns, NewTurn(message))
+	}
+
+	return turns
+}
@@ -0,0 +1,162 @@
+package design
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Format string
+
+const (
+	FORMAT_MERMAID  Format = "mermaid"
+	FORMAT_PLANTUML Format = "plantuml"
+)
+
+const (
+	// maxItems adalah jumlah maksimal komponen, data store, atau API dalam satu desain
+	maxItems = 50
+	// maxDiagram adalah panjang maksimal teks diagram
+	maxDiagram = 16 << 10
+)
+
+// Component adalah satu komponen sistem beserta tanggung jawabnya
+type Component struct {
+	Name           string
+	Responsibility string
+}
+
+// DataStore adalah penyimpanan data yang dipakai sistem, misalnya PostgreSQL atau Redis
+type DataStore struct {
+	Name    string
+	Kind    string
+	Purpose string
+}
+
+// API adalah endpoint yang disediakan sistem
+type API struct {
+	Method      string
+	Path        string
+	Description string
+}
+
+// Diagram berisi teks diagram Mermaid atau PlantUML
+type Diagram struct {
+	Format Format
+	Source string
+}
+
+// Edge adalah hubungan antar node hasil parsing diagram
+type Edge struct {
+	From  string
+	To    string
+	Label string `bson:",omitempty"`
+}
+
+// Design berisi desain sistem terstruktur yang dikirim user
+type Design struct {
+	Components []Component
+	DataStores []DataStore
+	APIs       []API
+	Diagram    Diagram
+	Notes      string `bson:",omitempty"`
+
+	// Edges diisi dari diagram oleh Parse
+	Edges []Edge `bson:",omitempty"`
+}
+
+// Parse digunakan untuk memvalidasi desain dan membaca hubungan antar node dari diagram
+func (d *Design) Parse() error {
+	if len(d.Components) == 0 {
+		return fmt.Errorf("design must have at least one component")
+	}
+	if len(d.Components) > maxItems || len(d.DataStores) > maxItems || len(d.APIs) > maxItems {
+		return fmt.Errorf("design must have at most %d components, data stores, and APIs", maxItems)
+	}
+
+	for _, component := range d.Components {
+		if strings.TrimSpace(component.Name) == "" {
+			return fmt.Errorf("component name is required")
+		}
+	}
+	for _, store := range d.DataStores {
+		if strings.TrimSpace(store.Name) == "" {
+			return fmt.Errorf("data store name is required")
+		}
+	}
+	for _, api := range d.APIs {
+		if strings.TrimSpace(api.Path) == "" {
+			return fmt.Errorf("API path is required")
+		}
+	}
+
+	d.Edges = nil
+	if strings.TrimSpace(d.Diagram.Source) == "" {
+		return nil
+	}
+	if len(d.Diagram.Source) > maxDiagram {
+		return fmt.Errorf("diagram must be at most %d bytes", maxDiagram)
+	}
+
+	edges, err := parseDiagram(d.Diagram)
+	if err != nil {
+		return err
+	}
+	d.Edges = edges
+
+	return nil
+}
+
+// Summary digunakan untuk menulis desain sebagai teks yang bisa dibaca AI
+func (d Design) Summary() string {
+	var b strings.Builder
+
+	b.WriteString("Components:\n")
+	for _, component := range d.Components {
+		writeItem(&b, component.Name, component.Responsibility)
+	}
+
+	if len(d.DataStores) > 0 {
+		b.WriteString("\nData stores:\n")
+		for _, store := range d.DataStores {
+			name := store.Name
+			if store.Kind != "" {
+				name = fmt.Sprintf("%s (%s)", store.Name, store.Kind)
+			}
+			writeItem(&b, name, store.Purpose)
+		}
+	}
+
+	if len(d.APIs) > 0 {
+		b.WriteString("\nAPIs:\n")
+		for _, api := range d.APIs {
+			writeItem(&b, strings.TrimSpace(api.Method+" "+api.Path), api.Description)
+		}
+	}
+
+	if len(d.Edges) > 0 {
+		b.WriteString("\nConnections from the diagram:\n")
+		for _, edge := range d.Edges {
+			writeItem(&b, fmt.Sprintf("%s -> %s", edge.From, edge.To), edge.Label)
+		}
+	}
+
+	if d.Diagram.Source != "" {
+		fmt.Fprintf(&b, "\nDiagram (%s):\n```\n%s\n```\n", d.Diagram.Format, strings.TrimSpace(d.Diagram.Source))
+	}
+
+	if d.Notes != "" {
+		fmt.Fprintf(&b, "\nNotes:\n%s\n", d.Notes)
+	}
+
+	return b.String()
+}
+
+// writeItem digunakan untuk menulis satu baris daftar dengan keterangan opsional
+func writeItem(b *strings.Builder, name, detail string) {
+	if detail == "" {
+		fmt.Fprintf(b, "- %s\n", name)
+		return
+	}
+
+	fmt.Fprintf(b, "- %s: %s\n", name, detail)
+}
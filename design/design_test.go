@@ -0,0 +1,174 @@
+package design
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	components := []Component{{Name: "API", Responsibility: "Accepts orders"}}
+
+	tests := []struct {
+		name    string
+		design  Design
+		want    []Edge
+		wantErr bool
+	}{
+		{
+			name: "mermaid flowchart",
+			design: Design{Components: components, Diagram: Diagram{Format: FORMAT_MERMAID, Source: `
+flowchart LR
+  %% node dengan label
+  api[API Gateway] -->|publish| queue[(Kafka)]
+  queue --> worker(Worker) --> db[(Postgres)]
+  worker -- read --> cache{{Redis}}
+`}},
+			want: []Edge{
+				{From: "API Gateway", To: "Kafka", Label: "publish"},
+				{From: "Kafka", To: "Worker"},
+				{From: "Worker", To: "Postgres"},
+				{From: "Worker", To: "Redis", Label: "read"},
+			},
+		},
+		{
+			name: "mermaid sequence diagram",
+			design: Design{Components: components, Diagram: Diagram{Format: FORMAT_MERMAID, Source: `sequenceDiagram
+  client->>api: POST /orders
+  api-->>client: 202 Accepted`}},
+			want: []Edge{
+				{From: "client", To: "api", Label: "POST /orders"},
+				{From: "api", To: "client", Label: "202 Accepted"},
+			},
+		},
+		{
+			name: "plantuml",
+			design: Design{Components: components, Diagram: Diagram{Format: FORMAT_PLANTUML, Source: `@startuml
+' komponen
+[API] --> [Queue] : publish
+"Worker" ..> Database
+@enduml`}},
+			want: []Edge{
+				{From: "API", To: "Queue", Label: "publish"},
+				{From: "Worker", To: "Database"},
+			},
+		},
+		{
+			name:   "without diagram",
+			design: Design{Components: components},
+		},
+		{
+			name:   "blank diagram is ignored",
+			design: Design{Components: components, Diagram: Diagram{Format: FORMAT_MERMAID, Source: " \n\t"}},
+		},
+		{
+			name:    "empty design",
+			design:  Design{},
+			wantErr: true,
+		},
+		{
+			name:    "component without name",
+			design:  Design{Components: []Component{{Name: "  ", Responsibility: "Accepts orders"}}},
+			wantErr: true,
+		},
+		{
+			name:    "data store without name",
+			design:  Design{Components: components, DataStores: []DataStore{{Kind: "PostgreSQL"}}},
+			wantErr: true,
+		},
+		{
+			name:    "API without path",
+			design:  Design{Components: components, APIs: []API{{Method: "GET"}}},
+			wantErr: true,
+		},
+		{
+			name:    "too many components",
+			design:  Design{Components: make([]Component, maxItems+1)},
+			wantErr: true,
+		},
+		{
+			name:    "diagram too large",
+			design:  Design{Components: components, Diagram: Diagram{Format: FORMAT_MERMAID, Source: "graph LR\n" + strings.Repeat("a --> b\n", maxDiagram/8)}},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported diagram format",
+			design:  Design{Components: components, Diagram: Diagram{Format: "graphviz", Source: "digraph { a -> b }"}},
+			wantErr: true,
+		},
+		{
+			name:    "mermaid without diagram type",
+			design:  Design{Components: components, Diagram: Diagram{Format: FORMAT_MERMAID, Source: "a --> b"}},
+			wantErr: true,
+		},
+		{
+			name:    "mermaid with only comments",
+			design:  Design{Components: components, Diagram: Diagram{Format: FORMAT_MERMAID, Source: "%% nothing here"}},
+			wantErr: true,
+		},
+		{
+			name:    "plantuml without enduml",
+			design:  Design{Components: components, Diagram: Diagram{Format: FORMAT_PLANTUML, Source: "@startuml\n[API] --> [Queue]"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			design := tt.design
+			// hubungan lama selalu diganti hasil parsing diagram
+			design.Edges = []Edge{{From: "stale", To: "edge"}}
+
+			err := design.Parse()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(design.Edges, tt.want) {
+				t.Errorf("Parse() edges = %+v, want %+v", design.Edges, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummary(t *testing.T) {
+	tests := []struct {
+		name   string
+		design Design
+		want   string
+	}{
+		{
+			name:   "components only",
+			design: Design{Components: []Component{{Name: "API", Responsibility: "Accepts orders"}, {Name: "Worker"}}},
+			want:   "Components:\n- API: Accepts orders\n- Worker\n",
+		},
+		{
+			name: "full design",
+			design: Design{
+				Components: []Component{{Name: "API", Responsibility: "Accepts orders"}},
+				DataStores: []DataStore{{Name: "orders", Kind: "PostgreSQL", Purpose: "Order history"}, {Name: "cache"}},
+				APIs:       []API{{Method: "POST", Path: "/orders", Description: "Create an order"}, {Path: "/health"}},
+				Diagram:    Diagram{Format: FORMAT_MERMAID, Source: "\ngraph LR\n  API --> Queue\n"},
+				Edges:      []Edge{{From: "API", To: "Queue"}, {From: "Queue", To: "Worker", Label: "consume"}},
+				Notes:      "Orders are idempotent.",
+			},
+			want: "Components:\n- API: Accepts orders\n" +
+				"\nData stores:\n- orders (PostgreSQL): Order history\n- cache\n" +
+				"\nAPIs:\n- POST /orders: Create an order\n- /health\n" +
+				"\nConnections from the diagram:\n- API -> Queue\n- Queue -> Worker: consume\n" +
+				"\nDiagram (mermaid):\n```\ngraph LR\n  API --> Queue\n```\n" +
+				"\nNotes:\nOrders are idempotent.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.design.Summary(); got != tt.want {
+				t.Errorf("Summary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package design
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// mermaidHeaders adalah jenis diagram Mermaid yang diterima
+var mermaidHeaders = []string{"graph", "flowchart", "sequenceDiagram", "classDiagram", "erDiagram", "C4Context", "C4Container", "C4Component"}
+
+var (
+	// mermaidNode mencocokkan node flowchart beserta labelnya, misalnya api[API Gateway] atau db[(Postgres)]
+	mermaidNode = regexp.MustCompile(`([A-Za-z0-9_]+)\s*(?:\[\(|\(\(|\[\[|\{\{|\[|\(|\{)([^\]\)\}]*)`)
+	// mermaidFlowEdge mencocokkan hubungan flowchart, misalnya a -->|read| b atau a -- read --> b
+	mermaidFlowEdge = regexp.MustCompile(`([A-Za-z0-9_]+)(?:\s*(?:\[\(|\(\(|\[\[|\{\{|\[|\(|\{)[^\]\)\}]*[\]\)\}]+)?\s*(?:--\s*([^->|]+?)\s*)?(?:-->|---|-\.->|==>|-\.-)\s*(?:\|([^|]*)\|)?\s*([A-Za-z0-9_]+)`)
+	// mermaidSequenceEdge mencocokkan pesan sequence diagram, misalnya client->>api: POST /orders
+	mermaidSequenceEdge = regexp.MustCompile(`^\s*([A-Za-z0-9_]+)\s*--?>>?[+-]?\s*([A-Za-z0-9_]+)\s*:\s*(.*)$`)
+	// plantUMLEdge mencocokkan hubungan PlantUML, misalnya [API] --> [Queue] : publish
+	plantUMLEdge = regexp.MustCompile(`^\s*("[^"]+"|\[[^\]]+\]|[A-Za-z0-9_.]+)\s*[-.]+(?:\[[^\]]*\])?[-.]*>\s*("[^"]+"|\[[^\]]+\]|[A-Za-z0-9_.]+)\s*(?::\s*(.*))?$`)
+)
+
+// parseDiagram digunakan untuk membaca hubungan antar node dari diagram
+func parseDiagram(diagram Diagram) ([]Edge, error) {
+	switch diagram.Format {
+	case FORMAT_MERMAID:
+		return parseMermaid(diagram.Source)
+	case FORMAT_PLANTUML:
+		return parsePlantUML(diagram.Source)
+	default:
+		return nil, fmt.Errorf("unsupported diagram format: %s", diagram.Format)
+	}
+}
+
+// parseMermaid digunakan untuk membaca hubungan dari flowchart atau sequence diagram Mermaid
+func parseMermaid(source string) ([]Edge, error) {
+	lines := contentLines(source, "%%")
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("mermaid diagram is empty")
+	}
+
+	header := strings.Fields(lines[0])[0]
+	known := false
+	for _, h := range mermaidHeaders {
+		if header == h {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("mermaid diagram must start with a diagram type such as flowchart or sequenceDiagram")
+	}
+
+	// label node dipakai agar hubungan mudah dibaca, misalnya "API Gateway" bukan "api"
+	labels := make(map[string]string)
+	for _, line := range lines[1:] {
+		for _, match := range mermaidNode.FindAllStringSubmatch(line, -1) {
+			if label := strings.Trim(match[2], `"' `); label != "" {
+				labels[match[1]] = label
+			}
+		}
+	}
+	name := func(id string) string {
+		if label, ok := labels[id]; ok {
+			return label
+		}
+		return id
+	}
+
+	var edges []Edge
+	for _, line := range lines[1:] {
+		if header == "sequenceDiagram" {
+			if match := mermaidSequenceEdge.FindStringSubmatch(line); match != nil {
+				edges = append(edges, Edge{From: match[1], To: match[2], Label: strings.TrimSpace(match[3])})
+			}
+			continue
+		}
+
+		// hubungan berantai seperti a --> b --> c dibaca mulai dari node tujuan sebelumnya
+		for rest := line; ; {
+			match := mermaidFlowEdge.FindStringSubmatchIndex(rest)
+			if match == nil {
+				break
+			}
+
+			label := strings.TrimSpace(submatch(rest, match, 3))
+			if label == "" {
+				label = strings.TrimSpace(submatch(rest, match, 2))
+			}
+			edges = append(edges, Edge{From: name(submatch(rest, match, 1)), To: name(submatch(rest, match, 4)), Label: label})
+
+			rest = rest[match[8]:]
+		}
+	}
+
+	return edges, nil
+}
+
+// parsePlantUML digunakan untuk membaca hubungan dari diagram PlantUML
+func parsePlantUML(source string) ([]Edge, error) {
+	lines := contentLines(source, "'")
+	if len(lines) < 2 || !strings.HasPrefix(lines[0], "@startuml") || !strings.HasPrefix(lines[len(lines)-1], "@enduml") {
+		return nil, fmt.Errorf("plantuml diagram must be wrapped in @startuml and @enduml")
+	}
+
+	var edges []Edge
+	for _, line := range lines[1 : len(lines)-1] {
+		match := plantUMLEdge.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		edges = append(edges, Edge{
+			From:  strings.Trim(match[1], `"[]`),
+			To:    strings.Trim(match[2], `"[]`),
+			Label: strings.TrimSpace(match[3]),
+		})
+	}
+
+	return edges, nil
+}
+
+// submatch digunakan untuk mengambil teks grup ke-n dari hasil FindStringSubmatchIndex
+func submatch(s string, match []int, n int) string {
+	if match[2*n] < 0 {
+		return ""
+	}
+
+	return s[match[2*n]:match[2*n+1]]
+}
+
+// contentLines digunakan untuk mengambil baris diagram tanpa baris kosong dan komentar
+func contentLines(source, comment string) []string {
+	var lines []string
+	for _, line := range strings.Split(source, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, comment) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
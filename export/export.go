@@ -0,0 +1,219 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+)
+
+type Format string
+
+const (
+	FORMAT_MARKDOWN Format = "md"
+	FORMAT_HTML     Format = "html"
+	FORMAT_PDF      Format = "pdf"
+	FORMAT_JSON     Format = "json"
+)
+
+// ParseFormat digunakan untuk memvalidasi format export dari query, kosong berarti Markdown
+func ParseFormat(format string) (Format, bool) {
+	switch f := Format(strings.ToLower(format)); f {
+	case "":
+		return FORMAT_MARKDOWN, true
+	case FORMAT_MARKDOWN, FORMAT_HTML, FORMAT_PDF, FORMAT_JSON:
+		return f, true
+	default:
+		return "", false
+	}
+}
+
+// ContentType digunakan untuk mendapatkan content type file export
+func (f Format) ContentType() string {
+	switch f {
+	case FORMAT_HTML:
+		return "text/html; charset=utf-8"
+	case FORMAT_PDF:
+		return "application/pdf"
+	case FORMAT_JSON:
+		return "application/json"
+	default:
+		return "text/markdown; charset=utf-8"
+	}
+}
+
+// Render digunakan untuk membuat dokumen export dari transcript interview.
+// Hasilnya hanya bergantung pada isi transcript, termasuk ExportedAt, sehingga bisa dibandingkan dengan file golden
+func Render(format Format, transcript model.Transcript) ([]byte, error) {
+	switch format {
+	case FORMAT_MARKDOWN:
+		return Markdown(transcript), nil
+	case FORMAT_HTML:
+		return HTML(transcript)
+	case FORMAT_PDF:
+		return PDF(transcript), nil
+	case FORMAT_JSON:
+		body, err := json.MarshalIndent(transcript, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		return append(body, '\n'), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// author digunakan untuk mendapatkan nama pengirim pesan di transcript
+func author(message model.TranscriptMessage) string {
+	switch {
+	case message.Role == "user":
+		return fmt.Sprintf("Interviewee (answer %d)", message.Answer)
+	case message.Speaker != nil:
+		return fmt.Sprintf("%s, %s", message.Speaker.Name, message.Speaker.Role)
+	default:
+		return "Interviewer"
+	}
+}
+
+// elapsed digunakan untuk menampilkan waktu pesan sejak interview dimulai,
+// kosong jika waktu pesan tidak tersimpan
+func elapsed(transcript model.Transcript, message model.TranscriptMessage) string {
+	if message.CreatedAt.IsZero() || transcript.StartedAt.IsZero() {
+		return ""
+	}
+
+	d := message.CreatedAt.Sub(transcript.StartedAt).Round(time.Second)
+	if d < 0 {
+		d = 0
+	}
+
+	return fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
+
+// timestamp digunakan untuk menampilkan waktu dalam UTC agar hasil export sama di setiap server
+func timestamp(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return t.UTC().Format("2006-01-02 15:04:05 UTC")
+}
+
+// status digunakan untuk menampilkan status interview
+func status(transcript model.Transcript) string {
+	if transcript.Finished {
+		return "finished"
+	}
+
+	return "in progress (" + transcript.Stage + ")"
+}
+
+// percent digunakan untuk menampilkan nilai 0 sampai 1 sebagai persen
+func percent(score float64) string {
+	return fmt.Sprintf("%.0f%%", score*100)
+}
+
+// overall digunakan untuk menampilkan nilai keseluruhan beserta nilai AI jika diubah oleh reviewer
+func overall(scorecard model.Scorecard) string {
+	if scorecard.AutomatedOverall == nil {
+		return percent(scorecard.Overall)
+	}
+
+	return fmt.Sprintf("%s (automated %s)", percent(scorecard.Overall), percent(*scorecard.AutomatedOverall))
+}
+
+// reviewStatus digunakan untuk menampilkan status review
+func reviewStatus(review model.Review) string {
+	status := "not reviewed yet"
+	if review.Reviewed {
+		status = fmt.Sprintf("reviewed by %s on %s", review.ReviewedBy, timestamp(review.ReviewedAt))
+	}
+	if !review.Published {
+		status += ", not published"
+	}
+
+	return status
+}
+
+// commentLine digunakan untuk menampilkan komentar reviewer
+func commentLine(comment model.ReviewComment) string {
+	return fmt.Sprintf("Reviewer comment by %s: %s", comment.Author, comment.Text)
+}
+
+// overrideLines digunakan untuk menampilkan nilai kemampuan yang diubah reviewer per baris
+func overrideLines(scorecard model.Scorecard) []string {
+	var lines []string
+	for _, competency := range scorecard.Competencies {
+		if competency.Override == nil {
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("%s: level %d of %d (automated average %.2f) by %s. %s",
+			competency.Name, competency.Override.Level, competency.MaxLevel, competency.Average, competency.Override.Author, competency.Override.Justification))
+	}
+
+	return lines
+}
+
+// speechLines digunakan untuk menampilkan ringkasan analisis bicara per baris
+func speechLines(s model.SpeechSummary) []string {
+	fillers := make([]string, 0, len(s.Fillers))
+	for _, filler := range sortedKeys(s.Fillers) {
+		fillers = append(fillers, fmt.Sprintf("%s %d", filler, s.Fillers[filler]))
+	}
+
+	lines := []string{
+		fmt.Sprintf("Spoken answers: %d (%d words)", s.Answers, s.Words),
+		fmt.Sprintf("Speaking rate: %.0f words per minute", s.WordsPerMinute),
+		fmt.Sprintf("Average answer length: %.0f seconds", s.AverageAnswerSeconds),
+		fmt.Sprintf("Filler words: %d (%.1f per minute)", s.FillerCount, s.FillersPerMinute),
+		fmt.Sprintf("Long pauses: %d, the longest was %.1f seconds", s.LongPauses, s.LongestPauseSeconds),
+	}
+	if len(fillers) > 0 {
+		lines[3] += ": " + strings.Join(fillers, ", ")
+	}
+
+	return lines
+}
+
+// starLines digunakan untuk menampilkan analisis STAR per baris
+func starLines(answer model.BehavioralAnswer) []string {
+	lines := []string{
+		"Situation: " + orDash(answer.Situation),
+		"Task: " + orDash(answer.Task),
+		"Action: " + orDash(answer.Action),
+		"Result: " + orDash(answer.Result),
+	}
+	if len(answer.Missing) > 0 {
+		lines = append(lines, "Missing: "+strings.Join(answer.Missing, ", "))
+	}
+	for _, suggestion := range answer.Suggestions {
+		lines = append(lines, "Suggestion: "+suggestion)
+	}
+
+	return lines
+}
+
+// orDash digunakan untuk menampilkan bagian STAR yang kosong sebagai tanda strip
+func orDash(text string) string {
+	if strings.TrimSpace(text) == "" {
+		return "-"
+	}
+
+	return text
+}
+
+// sortedKeys digunakan untuk mengurutkan kata pengisi agar hasil export selalu sama
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,182 @@
+package export
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+)
+
+// update menulis ulang file golden dengan hasil render saat ini, jalankan dengan go test ./export -update
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// testTranscript digunakan untuk membuat transcript yang mengisi semua bagian export
+func testTranscript() model.Transcript {
+	startedAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	automated := 0.58
+
+	return model.Transcript{
+		ID:         "chat-1",
+		Template:   "backend-golang-senior",
+		Title:      "Senior Backend Engineer (Golang)",
+		Language:   "en",
+		Stage:      "wrap-up",
+		Finished:   true,
+		StartedAt:  startedAt,
+		ExportedAt: startedAt.Add(2 * time.Hour),
+		Messages: []model.TranscriptMessage{
+			{
+				Role:      "assistant",
+				Speaker:   &model.Speaker{ID: "lead", Name: "Rina", Role: "Engineering Lead"},
+				Stage:     "technical",
+				Text:      "How would you limit concurrent requests to a downstream service?",
+				CreatedAt: startedAt.Add(10 * time.Minute),
+			},
+			{
+				Answer:    1,
+				Role:      "user",
+				Stage:     "technical",
+				Text:      "I would use a buffered channel as a semaphore.\nUnder load I would add <b>backpressure</b> & timeouts — \"fail fast\".",
+				CreatedAt: startedAt.Add(11*time.Minute + 5*time.Second),
+				Comments: []model.ReviewComment{
+					{ID: "comment-1", Turn: 1, Author: "reviewer", Text: "Good start, missed | pipe | handling.", CreatedAt: startedAt.Add(3 * time.Hour)},
+				},
+			},
+			{
+				Role: "assistant",
+				Text: "Thanks, that is all from us.",
+			},
+		},
+		Scorecard: model.Scorecard{
+			Template:         "backend-golang-senior",
+			RubricVersion:    "abc123",
+			Overall:          0.67,
+			AutomatedOverall: &automated,
+			ScoredAnswers:    1,
+			Competencies: []model.CompetencyScore{
+				{ID: "technical-knowledge", Name: "Technical knowledge", Average: 3, MaxLevel: 4, Count: 1},
+				{
+					ID: "communication", Name: "Communication | clarity", Average: 2, MaxLevel: 4, Count: 1,
+					Override: &model.ScoreOverride{Level: 3, Justification: "Clear in the follow-up.", Author: "reviewer", At: startedAt.Add(3 * time.Hour)},
+				},
+			},
+			Answers: []model.AnswerScore{
+				{
+					Turn:  1,
+					Stage: "technical",
+					Scores: []model.Score{
+						{Competency: "technical-knowledge", Level: 3, Evidence: "Named a buffered channel semaphore."},
+						{Competency: "communication", Level: 2, Evidence: "Answer was short."},
+					},
+				},
+			},
+			Behavioral: []model.BehavioralAnswer{
+				{
+					Turn:        1,
+					Situation:   "Traffic spike on launch day.",
+					Action:      "Added a semaphore.",
+					Missing:     []string{"task", "result"},
+					Suggestions: []string{"Describe the measurable outcome."},
+				},
+			},
+			Speech: &model.SpeechSummary{
+				Answers:              1,
+				Words:                120,
+				SpeakingSeconds:      60,
+				WordsPerMinute:       120,
+				FillerCount:          3,
+				FillersPerMinute:     3,
+				Fillers:              map[string]int{"um": 2, "like": 1},
+				LongPauses:           1,
+				LongestPauseSeconds:  2.5,
+				AverageAnswerSeconds: 60,
+			},
+			Design: &model.Design{
+				Components:  []model.DesignComponent{{Name: "API", Responsibility: "Accepts requests"}},
+				Diagram:     model.DesignDiagram{Format: "mermaid", Source: "graph LR\n  API --> Queue"},
+				Edges:       []model.DesignEdge{{From: "API", To: "Queue"}},
+				SubmittedAt: startedAt.Add(40 * time.Minute),
+				Summary:     "Components:\n- API: Accepts requests\n\nConnections from the diagram:\n- API -> Queue\n\nDiagram (mermaid):\n```\ngraph LR\n  API --> Queue\n```\n",
+			},
+			Review: &model.Review{
+				Reviewed:   true,
+				ReviewedBy: "reviewer",
+				ReviewedAt: startedAt.Add(3 * time.Hour),
+				Published:  false,
+			},
+		},
+		Flags: []model.AnswerFlag{
+			{Answer: 1, Kind: "injection", Detail: "ignore previous instructions", Action: "neutralize"},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		format Format
+		golden string
+	}{
+		{format: FORMAT_MARKDOWN, golden: "transcript.md.golden"},
+		{format: FORMAT_HTML, golden: "transcript.html.golden"},
+		{format: FORMAT_PDF, golden: "transcript.pdf.golden"},
+		{format: FORMAT_JSON, golden: "transcript.json.golden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			got, err := Render(tt.format, testTranscript())
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read golden file, run go test ./export -update to create it: %v", err)
+			}
+
+			if !bytes.Equal(got, want) {
+				t.Errorf("Render(%s) does not match %s, run go test ./export -update and review the diff", tt.format, path)
+			}
+
+			// hasil render harus sama setiap kali, termasuk urutan kata pengisi dari map
+			again, err := Render(tt.format, testTranscript())
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if !bytes.Equal(got, again) {
+				t.Errorf("Render(%s) is not deterministic", tt.format)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		query string
+		want  Format
+		ok    bool
+	}{
+		{query: "", want: FORMAT_MARKDOWN, ok: true},
+		{query: "PDF", want: FORMAT_PDF, ok: true},
+		{query: "json", want: FORMAT_JSON, ok: true},
+		{query: "docx", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseFormat(tt.query)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseFormat(%q) = %q, %v, want %q, %v", tt.query, got, ok, tt.want, tt.ok)
+		}
+	}
+}
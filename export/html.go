@@ -0,0 +1,130 @@
+package export
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+)
+
+// htmlTemplate adalah halaman transcript yang bisa dibuka tanpa file lain
+var htmlTemplate = template.Must(template.New("transcript").Funcs(template.FuncMap{
+	"author":        author,
+	"elapsed":       elapsed,
+	"timestamp":     timestamp,
+	"status":        status,
+	"percent":       percent,
+	"overall":       overall,
+	"reviewStatus":  reviewStatus,
+	"commentLine":   commentLine,
+	"overrideLines": overrideLines,
+	"speechLines":   speechLines,
+	"starLines":     starLines,
+}).Parse(`<!DOCTYPE html>
+<html lang="{{.Language}}">
+<head>
+<meta charset="UTF-8">
+<title>Interview Transcript: {{.Title}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; max-width: 800px; margin: 40px auto; padding: 0 16px; color: #222; }
+.meta { color: #666; }
+.message { margin: 16px 0; padding: 12px; border-radius: 8px; background-color: #f2f2f2; }
+.message.user { background-color: #e3ecf7; }
+.author { font-weight: bold; margin-bottom: 6px; }
+.author .time, .author .stage { font-weight: normal; color: #666; }
+.text { white-space: pre-wrap; }
+.comment { margin-top: 8px; padding: 8px; border-left: 3px solid #d9a400; background-color: #fff8e1; white-space: pre-wrap; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+</style>
+</head>
+<body>
+<h1>Interview Transcript: {{.Title}}</h1>
+<ul class="meta">
+<li>Chat: {{.ID}}</li>
+<li>Template: {{.Template}}</li>
+<li>Language: {{.Language}}</li>
+<li>Status: {{status .}}</li>
+<li>Started: {{timestamp .StartedAt}}</li>
+<li>Exported: {{timestamp .ExportedAt}}</li>
+</ul>
+<h2>Transcript</h2>
+{{- $transcript := .}}
+{{- range .Messages}}
+<div class="message {{.Role}}">
+<div class="author">{{with elapsed $transcript .}}<span class="time">[{{.}}]</span> {{end}}{{author .}}{{with .Stage}} <span class="stage">({{.}})</span>{{end}}</div>
+<div class="text">{{.Text}}</div>
+{{- range .Comments}}
+<div class="comment">{{commentLine .}}</div>
+{{- end}}
+</div>
+{{- end}}
+{{- with .Scorecard}}
+<h2>Scorecard</h2>
+<p>Overall: <strong>{{overall .}}</strong> from {{.ScoredAnswers}} scored answers (rubric {{.RubricVersion}})</p>
+{{- if .Competencies}}
+<table>
+<tr><th>Competency</th><th>Average level</th><th>Answers</th></tr>
+{{- range .Competencies}}
+<tr><td>{{.Name}}</td><td>{{printf "%.2f" .Average}} / {{.MaxLevel}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- with overrideLines .}}
+<h3>Reviewer Overrides</h3>
+<ul>
+{{- range .}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- range .Answers}}
+<h3>Answer {{.Turn}} ({{.Stage}})</h3>
+<ul>
+{{- range .Scores}}
+<li>{{.Competency}}: level {{.Level}}. {{.Evidence}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Behavioral}}
+<h2>Behavioral Answers (STAR)</h2>
+{{- range .Behavioral}}
+<h3>Answer {{.Turn}}</h3>
+<ul>
+{{- range starLines .}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+{{- with .Speech}}
+<h2>Speech</h2>
+<ul>
+{{- range speechLines .}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- with .Design}}
+<h2>System Design</h2>
+<p>Submitted: {{timestamp .SubmittedAt}}</p>
+<div class="text">{{.Summary}}</div>
+{{- end}}
+{{- with .Review}}
+<h2>Review</h2>
+<p>Status: {{reviewStatus .}}</p>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+// HTML digunakan untuk membuat transcript interview dalam format HTML
+func HTML(transcript model.Transcript) ([]byte, error) {
+	var b bytes.Buffer
+	if err := htmlTemplate.Execute(&b, transcript); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
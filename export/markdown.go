@@ -0,0 +1,98 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+)
+
+// Markdown digunakan untuk membuat transcript interview dalam format Markdown
+func Markdown(transcript model.Transcript) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Interview Transcript: %s\n\n", transcript.Title)
+	fmt.Fprintf(&b, "- Chat: %s\n", transcript.ID)
+	fmt.Fprintf(&b, "- Template: %s\n", transcript.Template)
+	fmt.Fprintf(&b, "- Language: %s\n", transcript.Language)
+	fmt.Fprintf(&b, "- Status: %s\n", status(transcript))
+	fmt.Fprintf(&b, "- Started: %s\n", timestamp(transcript.StartedAt))
+	fmt.Fprintf(&b, "- Exported: %s\n", timestamp(transcript.ExportedAt))
+
+	b.WriteString("\n## Transcript\n")
+	for _, message := range transcript.Messages {
+		b.WriteString("\n**")
+		if at := elapsed(transcript, message); at != "" {
+			fmt.Fprintf(&b, "[%s] ", at)
+		}
+		b.WriteString(author(message))
+		b.WriteString("**")
+		if message.Stage != "" {
+			fmt.Fprintf(&b, " _(%s)_", message.Stage)
+		}
+		fmt.Fprintf(&b, "\n\n%s\n", strings.TrimSpace(message.Text))
+		for _, comment := range message.Comments {
+			fmt.Fprintf(&b, "\n> %s\n", strings.ReplaceAll(commentLine(comment), "\n", "\n> "))
+		}
+	}
+
+	scorecard := transcript.Scorecard
+	b.WriteString("\n## Scorecard\n\n")
+	fmt.Fprintf(&b, "Overall: **%s** from %d scored answers (rubric %s)\n", overall(scorecard), scorecard.ScoredAnswers, scorecard.RubricVersion)
+
+	if len(scorecard.Competencies) > 0 {
+		b.WriteString("\n| Competency | Average level | Answers |\n| --- | --- | --- |\n")
+		for _, competency := range scorecard.Competencies {
+			fmt.Fprintf(&b, "| %s | %.2f / %d | %d |\n", tableCell(competency.Name), competency.Average, competency.MaxLevel, competency.Count)
+		}
+	}
+
+	if lines := overrideLines(scorecard); len(lines) > 0 {
+		b.WriteString("\n### Reviewer Overrides\n\n")
+		for _, line := range lines {
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+	}
+
+	for _, answer := range scorecard.Answers {
+		fmt.Fprintf(&b, "\n### Answer %d (%s)\n\n", answer.Turn, answer.Stage)
+		for _, score := range answer.Scores {
+			fmt.Fprintf(&b, "- %s: level %d. %s\n", score.Competency, score.Level, score.Evidence)
+		}
+	}
+
+	if len(scorecard.Behavioral) > 0 {
+		b.WriteString("\n## Behavioral Answers (STAR)\n")
+		for _, answer := range scorecard.Behavioral {
+			fmt.Fprintf(&b, "\n### Answer %d\n\n", answer.Turn)
+			for _, line := range starLines(answer) {
+				fmt.Fprintf(&b, "- %s\n", line)
+			}
+		}
+	}
+
+	if scorecard.Speech != nil {
+		b.WriteString("\n## Speech\n\n")
+		for _, line := range speechLines(*scorecard.Speech) {
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+	}
+
+	if scorecard.Design != nil {
+		b.WriteString("\n## System Design\n\n")
+		fmt.Fprintf(&b, "Submitted: %s\n\n%s", timestamp(scorecard.Design.SubmittedAt), scorecard.Design.Summary)
+	}
+
+	if scorecard.Review != nil {
+		b.WriteString("\n## Review\n\n")
+		fmt.Fprintf(&b, "Status: %s\n", reviewStatus(*scorecard.Review))
+	}
+
+	return []byte(b.String())
+}
+
+// tableCell digunakan untuk mencegah isi sel memecah tabel Markdown
+func tableCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.ReplaceAll(text, "\n", " ")
+}
@@ -0,0 +1,101 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+)
+
+// PDF digunakan untuk membuat transcript interview dalam format PDF tanpa library eksternal,
+// teks ditulis dengan font standar PDF sehingga karakter di luar Latin-1 diganti tanda tanya
+func PDF(transcript model.Transcript) []byte {
+	w := newPDFWriter()
+
+	w.text("Interview Transcript: "+transcript.Title, fontBold, 16, 0, 0)
+	w.space(4)
+	meta := []string{
+		"Chat: " + transcript.ID,
+		"Template: " + transcript.Template,
+		"Language: " + transcript.Language,
+		"Status: " + status(transcript),
+		"Started: " + timestamp(transcript.StartedAt),
+		"Exported: " + timestamp(transcript.ExportedAt),
+	}
+	w.text(strings.Join(meta, "\n"), fontRegular, 9, 0, 0.4)
+
+	heading(w, "Transcript")
+	for _, message := range transcript.Messages {
+		label := author(message)
+		if at := elapsed(transcript, message); at != "" {
+			label = "[" + at + "] " + label
+		}
+		if message.Stage != "" {
+			label += " (" + message.Stage + ")"
+		}
+
+		w.space(6)
+		w.text(label, fontBold, 10, 0, 0)
+		w.text(strings.TrimSpace(message.Text), fontRegular, 10, 12, 0)
+		for _, comment := range message.Comments {
+			w.space(3)
+			w.text(commentLine(comment), fontRegular, 9, 24, 0.4)
+		}
+	}
+
+	scorecard := transcript.Scorecard
+	heading(w, "Scorecard")
+	w.text(fmt.Sprintf("Overall: %s from %d scored answers (rubric %s)", overall(scorecard), scorecard.ScoredAnswers, scorecard.RubricVersion), fontRegular, 10, 0, 0)
+	for _, competency := range scorecard.Competencies {
+		w.text(fmt.Sprintf("%s: average level %.2f of %d from %d answers", competency.Name, competency.Average, competency.MaxLevel, competency.Count), fontRegular, 10, 12, 0)
+	}
+
+	if lines := overrideLines(scorecard); len(lines) > 0 {
+		w.space(6)
+		w.text("Reviewer Overrides", fontBold, 10, 0, 0)
+		w.text(strings.Join(lines, "\n"), fontRegular, 10, 12, 0)
+	}
+
+	for _, answer := range scorecard.Answers {
+		w.space(6)
+		w.text(fmt.Sprintf("Answer %d (%s)", answer.Turn, answer.Stage), fontBold, 10, 0, 0)
+		for _, score := range answer.Scores {
+			w.text(fmt.Sprintf("%s: level %d. %s", score.Competency, score.Level, score.Evidence), fontRegular, 10, 12, 0)
+		}
+	}
+
+	if len(scorecard.Behavioral) > 0 {
+		heading(w, "Behavioral Answers (STAR)")
+		for _, answer := range scorecard.Behavioral {
+			w.space(6)
+			w.text(fmt.Sprintf("Answer %d", answer.Turn), fontBold, 10, 0, 0)
+			w.text(strings.Join(starLines(answer), "\n"), fontRegular, 10, 12, 0)
+		}
+	}
+
+	if scorecard.Speech != nil {
+		heading(w, "Speech")
+		w.text(strings.Join(speechLines(*scorecard.Speech), "\n"), fontRegular, 10, 12, 0)
+	}
+
+	if scorecard.Design != nil {
+		heading(w, "System Design")
+		w.text("Submitted: "+timestamp(scorecard.Design.SubmittedAt), fontRegular, 9, 0, 0.4)
+		w.space(4)
+		w.text(strings.TrimSpace(scorecard.Design.Summary), fontRegular, 10, 12, 0)
+	}
+
+	if scorecard.Review != nil {
+		heading(w, "Review")
+		w.text("Status: "+reviewStatus(*scorecard.Review), fontRegular, 10, 0, 0)
+	}
+
+	return w.render("Interview Transcript: "+transcript.Title, transcript.ExportedAt)
+}
+
+// heading digunakan untuk menulis judul bagian
+func heading(w *pdfWriter, title string) {
+	w.space(12)
+	w.text(title, fontBold, 13, 0, 0)
+	w.space(2)
+}
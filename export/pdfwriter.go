@@ -0,0 +1,253 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ukuran halaman A4 dan margin dalam point
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+	pageMargin = 50.0
+)
+
+type pdfFont int
+
+const (
+	fontRegular pdfFont = iota
+	fontBold
+)
+
+// name adalah nama resource font di setiap halaman
+func (f pdfFont) name() string {
+	if f == fontBold {
+		return "F2"
+	}
+
+	return "F1"
+}
+
+// helveticaWidths dan helveticaBoldWidths adalah lebar karakter ASCII 32 sampai 126 dari metrik font standar PDF,
+// dalam seperseribu ukuran font
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// winAnsi berisi karakter di luar Latin-1 yang tersedia di WinAnsiEncoding
+var winAnsi = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// encode digunakan untuk mengubah teks UTF-8 menjadi WinAnsiEncoding,
+// karakter yang tidak tersedia di font standar PDF diganti dengan tanda tanya
+func encode(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r == '\t':
+			encoded = append(encoded, "    "...)
+		case r >= 32 && r <= 126, r >= 0xA0 && r <= 0xFF:
+			encoded = append(encoded, byte(r))
+		case winAnsi[r] != 0:
+			encoded = append(encoded, winAnsi[r])
+		case r == utf8.RuneError || r < 32:
+			continue
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+
+	return encoded
+}
+
+// width digunakan untuk menghitung lebar teks yang sudah di-encode dalam point
+func (f pdfFont) width(text []byte, size float64) float64 {
+	widths := &helveticaWidths
+	if f == fontBold {
+		widths = &helveticaBoldWidths
+	}
+
+	total := 0
+	for _, c := range text {
+		switch {
+		case c >= 32 && c <= 126:
+			total += widths[c-32]
+		case c == 0x97 || c == 0x85:
+			total += 1000
+		default:
+			// karakter Latin-1 dan tanda baca WinAnsi lainnya tidak lebih lebar dari angka
+			total += 556
+		}
+	}
+
+	return float64(total) * size / 1000
+}
+
+// pdfWriter menyusun teks menjadi halaman PDF, setiap baris yang melewati batas halaman dipindah ke halaman baru
+type pdfWriter struct {
+	pages []*bytes.Buffer
+	// y adalah posisi baris berikutnya dari bawah halaman
+	y float64
+}
+
+func newPDFWriter() *pdfWriter {
+	w := &pdfWriter{}
+	w.newPage()
+
+	return w
+}
+
+func (w *pdfWriter) newPage() {
+	w.pages = append(w.pages, &bytes.Buffer{})
+	w.y = pageHeight - pageMargin
+}
+
+// space digunakan untuk menambahkan jarak vertikal
+func (w *pdfWriter) space(height float64) {
+	w.y -= height
+}
+
+// text digunakan untuk menulis teks yang dipotong per kata sesuai lebar halaman,
+// baris baru di teks tetap dipertahankan
+func (w *pdfWriter) text(text string, font pdfFont, size, indent, gray float64) {
+	lineHeight := size * 1.4
+	maxWidth := pageWidth - 2*pageMargin - indent
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		for _, line := range wrap(encode(paragraph), font, size, maxWidth) {
+			if w.y-lineHeight < pageMargin {
+				w.newPage()
+			}
+			w.y -= lineHeight
+
+			if len(line) == 0 {
+				continue
+			}
+			fmt.Fprintf(w.pages[len(w.pages)-1], "BT %.3g g /%s %g Tf %.2f %.2f Td (%s) Tj ET\n",
+				gray, font.name(), size, pageMargin+indent, w.y, escape(line))
+		}
+	}
+}
+
+// wrap digunakan untuk memecah teks menjadi baris yang tidak melebihi lebar maksimal,
+// kata yang lebih panjang dari satu baris dipotong per karakter
+func wrap(text []byte, font pdfFont, size, maxWidth float64) [][]byte {
+	var lines [][]byte
+	var line []byte
+
+	for _, word := range bytes.Split(text, []byte(" ")) {
+		candidate := word
+		if len(line) > 0 {
+			candidate = append(append(append([]byte{}, line...), ' '), word...)
+		}
+		if font.width(candidate, size) <= maxWidth {
+			line = candidate
+			continue
+		}
+
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+		line = nil
+
+		for font.width(word, size) > maxWidth {
+			cut := 1
+			for cut < len(word) && font.width(word[:cut+1], size) <= maxWidth {
+				cut++
+			}
+			lines = append(lines, word[:cut])
+			word = word[cut:]
+		}
+		line = word
+	}
+
+	return append(lines, line)
+}
+
+// escape digunakan untuk menulis teks sebagai string literal PDF,
+// karakter non-ASCII ditulis dalam oktal agar file tetap ASCII
+func escape(text []byte) string {
+	var b strings.Builder
+	for _, c := range text {
+		switch {
+		case c == '\\' || c == '(' || c == ')':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 32 || c > 126:
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+// render digunakan untuk menyusun file PDF dari semua halaman beserta nomor halaman di bagian bawah
+func (w *pdfWriter) render(title string, createdAt time.Time) []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// objek 1 sampai 5 adalah katalog, daftar halaman, dua font, dan info dokumen,
+	// setiap halaman terdiri dari objek halaman dan objek isi
+	kids := make([]string, len(w.pages))
+	for i := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	info := fmt.Sprintf("<< /Title (%s) /Producer (ai-interview)", escape(encode(title)))
+	if !createdAt.IsZero() {
+		info += fmt.Sprintf(" /CreationDate (D:%s)", createdAt.UTC().Format("20060102150405Z"))
+	}
+	object(info + " >>")
+
+	for i, page := range w.pages {
+		footer := encode(fmt.Sprintf("Page %d of %d", i+1, len(w.pages)))
+		content := page.String() + fmt.Sprintf("BT 0.5 g /F1 8 Tf %.2f %.2f Td (%s) Tj ET\n",
+			pageWidth-pageMargin-fontRegular.width(footer, 8), pageMargin/2, escape(footer))
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 7+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
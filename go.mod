@@ -8,6 +8,7 @@ require (
 	github.com/google/uuid v1.6.0
 	go.mongodb.org/mongo-driver v1.15.0
 	golang.org/x/crypto v0.17.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,76 @@
+package guard
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+)
+
+// Moderator mengecek apakah teks melanggar kebijakan konten,
+// mengembalikan kategori pelanggaran atau kosong jika aman
+type Moderator interface {
+	Moderate(string) ([]string, error)
+}
+
+// OpenAIModerator menggunakan endpoint moderation dari ai.Client
+type OpenAIModerator struct {
+	client ai.Client
+}
+
+// NewOpenAIModerator digunakan untuk membuat moderator berbasis OpenAI
+func NewOpenAIModerator(client ai.Client) *OpenAIModerator {
+	return &OpenAIModerator{client: client}
+}
+
+func (m *OpenAIModerator) Moderate(text string) ([]string, error) {
+	resp, err := m.client.Moderate(text)
+	if err != nil {
+		return nil, err
+	}
+
+	var categories []string
+	for _, result := range resp.Results {
+		if !result.Flagged {
+			continue
+		}
+
+		for category, flagged := range result.Categories {
+			if flagged {
+				categories = append(categories, category)
+			}
+		}
+	}
+	sort.Strings(categories)
+
+	return categories, nil
+}
+
+// LocalModerator mengecek teks dengan daftar kata kunci tanpa memanggil API
+type LocalModerator struct {
+	categories map[string]*regexp.Regexp
+}
+
+// NewLocalModerator digunakan untuk membuat moderator berbasis kata kunci
+func NewLocalModerator() *LocalModerator {
+	return &LocalModerator{
+		categories: map[string]*regexp.Regexp{
+			"harassment": regexp.MustCompile(`(?i)\b(idiot|stupid bot|shut up|bodoh|goblok|tolol|bangsat)\b`),
+			"violence":   regexp.MustCompile(`(?i)\b(kill you|bomb|shoot (you|them)|bunuh kamu)\b`),
+			"self-harm":  regexp.MustCompile(`(?i)\b(kill myself|suicide|bunuh diri)\b`),
+			"sexual":     regexp.MustCompile(`(?i)\b(porn|nude|sex(ual)? favou?r)\b`),
+		},
+	}
+}
+
+func (m *LocalModerator) Moderate(text string) ([]string, error) {
+	var categories []string
+	for category, pattern := range m.categories {
+		if pattern.MatchString(text) {
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+
+	return categories, nil
+}
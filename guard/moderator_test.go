@@ -0,0 +1,89 @@
+package guard
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+)
+
+// fakeClient adalah ai.Client yang membalas Moderate dengan respons tetap atau err jika diisi,
+// method lain tidak dipakai di test ini
+type fakeClient struct {
+	ai.Client
+	moderation ai.ModerationResponse
+	err        error
+}
+
+func (f fakeClient) Moderate(string) (ai.ModerationResponse, error) {
+	return f.moderation, f.err
+}
+
+func TestOpenAIModerator(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  fakeClient
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "flagged categories are sorted",
+			client: fakeClient{moderation: ai.ModerationResponse{Results: []ai.ModerationResult{{
+				Flagged:    true,
+				Categories: map[string]bool{"violence": true, "harassment": true, "sexual": false},
+			}}}},
+			want: []string{"harassment", "violence"},
+		},
+		{
+			name: "unflagged result is ignored",
+			client: fakeClient{moderation: ai.ModerationResponse{Results: []ai.ModerationResult{{
+				Categories: map[string]bool{"harassment": true},
+			}}}},
+		},
+		{
+			name:    "moderation error",
+			client:  fakeClient{err: errors.New("rate limited")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewOpenAIModerator(tt.client).Moderate("answer")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Moderate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Moderate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalModerator(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "clean answer", text: "I would kill the goroutine with a context cancel."},
+		{name: "harassment", text: "Shut up, you stupid bot.", want: []string{"harassment"}},
+		{name: "indonesian", text: "Dasar bodoh, aku mau bunuh diri.", want: []string{"harassment", "self-harm"}},
+	}
+
+	moderator := NewLocalModerator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := moderator.Moderate(tt.text)
+			if err != nil {
+				t.Fatalf("Moderate() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Moderate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
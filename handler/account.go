@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/access"
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+	"github.com/go-chi/chi"
+)
+
+const (
+	// minAdminTokenSize adalah panjang minimal token admin dari konfigurasi
+	minAdminTokenSize = 16
+
+	// accountTokenSize adalah jumlah byte acak token akun yang dibuat melalui API
+	accountTokenSize = 32
+
+	// maxAccountRequestSize adalah ukuran maksimal body permintaan membuat akun
+	maxAccountRequestSize = 4 << 10
+)
+
+// adminTokens berisi nama pemilik token admin dari konfigurasi berdasarkan hash token,
+// token tidak disimpan apa adanya agar tidak ikut terbaca dari memori atau log
+type adminTokens map[string]string
+
+// parseAdminTokens digunakan untuk membaca token admin dengan format "nama:token,nama:token"
+func parseAdminTokens(s string) (adminTokens, error) {
+	tokens := make(adminTokens)
+	if s == "" {
+		return tokens, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		name, token, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("admin token must use the name:token format")
+		}
+		if len(token) < minAdminTokenSize {
+			return nil, fmt.Errorf("admin token of %s must be at least %d characters", name, minAdminTokenSize)
+		}
+
+		tokens[hashToken(token)] = name
+	}
+
+	return tokens, nil
+}
+
+// hashToken digunakan untuk membuat hash token yang disimpan dan dicari di penyimpanan akun
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// lookupToken digunakan untuk mendapatkan pemilik token, token admin dari konfigurasi dicek lebih dulu
+// sehingga admin tetap bisa masuk untuk membuat akun pertama
+func (h *handler) lookupToken(token string) (access.Principal, error) {
+	hash := hashToken(token)
+	if name, ok := h.admins[hash]; ok {
+		return access.Principal{Name: name, Role: access.ROLE_ADMIN}, nil
+	}
+
+	account, err := h.accounts.GetAccountByToken(hash)
+	if err != nil {
+		return access.Principal{}, err
+	}
+
+	return access.Principal{ID: account.ID, Name: account.Name, Role: account.Role}, nil
+}
+
+func (h *handler) ListAccounts(w http.ResponseWriter, req *http.Request) {
+	accounts, err := h.accounts.ListAccounts()
+	if err != nil {
+		log.Printf("failed to list accounts: %v", err)
+		sendResponse(w, nil, "failed to list accounts", http.StatusInternalServerError)
+
+		return
+	}
+
+	response := []model.Account{}
+	for _, account := range accounts {
+		response = append(response, accountResponse(account))
+	}
+
+	sendResponse(w, response, "success", http.StatusOK)
+}
+
+func (h *handler) CreateAccount(w http.ResponseWriter, req *http.Request) {
+	var body model.CreateAccountRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxAccountRequestSize)).Decode(&body); err != nil {
+		sendResponse(w, nil, "invalid request body", http.StatusBadRequest)
+
+		return
+	}
+
+	body.Name = strings.TrimSpace(body.Name)
+	if body.Name == "" {
+		sendResponse(w, nil, "name is required", http.StatusBadRequest)
+
+		return
+	}
+
+	// kandidat tidak memakai akun karena aksesnya berasal dari sesi interview
+	role, err := access.ParseRole(body.Role)
+	if err != nil || role == access.ROLE_CANDIDATE {
+		sendResponse(w, nil, "role must be one of reviewer, admin, or service", http.StatusBadRequest)
+
+		return
+	}
+
+	secret := make([]byte, accountTokenSize)
+	if _, err := rand.Read(secret); err != nil {
+		log.Printf("failed to generate account token: %v", err)
+		sendResponse(w, nil, "failed to create account", http.StatusInternalServerError)
+
+		return
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	account := data.Account{
+		Name:      body.Name,
+		Role:      role,
+		TokenHash: hashToken(token),
+		CreatedAt: time.Now(),
+	}
+
+	account.ID, err = h.accounts.InsertAccount(account)
+	if err != nil {
+		log.Printf("failed to insert account: %v", err)
+		sendResponse(w, nil, "failed to create account", http.StatusInternalServerError)
+
+		return
+	}
+
+	response := accountResponse(account)
+	response.Token = token
+
+	sendResponse(w, response, "success", http.StatusOK)
+}
+
+func (h *handler) DeleteAccount(w http.ResponseWriter, req *http.Request) {
+	err := h.accounts.DeleteAccount(chi.URLParam(req, "id"))
+	if errors.Is(err, data.ErrAccountNotFound) {
+		sendResponse(w, nil, "account not found", http.StatusNotFound)
+
+		return
+	}
+	if err != nil {
+		log.Printf("failed to delete account: %v", err)
+		sendResponse(w, nil, "failed to delete account", http.StatusInternalServerError)
+
+		return
+	}
+
+	sendResponse(w, nil, "account deleted", http.StatusOK)
+}
+
+// accountResponse digunakan untuk mengubah akun menjadi respons API tanpa token
+func accountResponse(account data.Account) model.Account {
+	response := model.Account{
+		ID:          account.ID,
+		Name:        account.Name,
+		Role:        string(account.Role),
+		Permissions: []string{},
+		CreatedAt:   account.CreatedAt,
+	}
+
+	for _, permission := range account.Role.Permissions() {
+		response.Permissions = append(response.Permissions, string(permission))
+	}
+
+	return response
+}
@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+	"github.com/go-chi/chi"
+)
+
+const (
+	// defaultAdminPageSize dan maxAdminPageSize adalah jumlah chat per halaman daftar admin
+	defaultAdminPageSize = 20
+	maxAdminPageSize     = 100
+)
+
+func (h *handler) ListChats(w http.ResponseWriter, req *http.Request) {
+	filter, err := chatFilter(req.URL.Query())
+	if err != nil {
+		sendResponse(w, nil, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	page, err := h.db.ListChats(filter)
+	if err != nil {
+		log.Printf("failed to list chats: %v", err)
+		sendResponse(w, nil, "failed to list chats", http.StatusInternalServerError)
+
+		return
+	}
+
+	response := model.AdminChatList{
+		Chats: []model.AdminChat{},
+	}
+	for _, entry := range page.Chats {
+		response.Chats = append(response.Chats, h.adminChatResponse(entry))
+	}
+
+	// halaman pencarian chat terenkripsi bisa berisi kurang dari limit tetapi masih memiliki cursor
+	if page.Next != nil {
+		response.NextCursor = page.Next.Encode()
+	}
+
+	sendResponse(w, response, "success", http.StatusOK)
+}
+
+func (h *handler) GetAdminChat(w http.ResponseWriter, req *http.Request) {
+	entry, ok := h.getChat(w, req)
+	if !ok {
+		return
+	}
+
+	review, ok := h.reviewFor(w, req, entry.ID)
+	if !ok {
+		return
+	}
+
+	// audio tidak disertakan karena hanya bisa diambil oleh pemilik chat atau melalui link share
+	transcript := h.transcript(entry, time.Now(), noAudioURL, review, true)
+	transcript.Flags = flagsResponse(entry)
+
+	sendResponse(w, transcript, "success", http.StatusOK)
+}
+
+func (h *handler) ExportAdminChat(w http.ResponseWriter, req *http.Request) {
+	entry, ok := h.getChat(w, req)
+	if !ok {
+		return
+	}
+
+	h.sendExport(w, req, entry, noAudioURL, true)
+}
+
+func (h *handler) GetAdminScores(w http.ResponseWriter, req *http.Request) {
+	entry, ok := h.getChat(w, req)
+	if !ok {
+		return
+	}
+
+	review, ok := h.reviewFor(w, req, entry.ID)
+	if !ok {
+		return
+	}
+
+	sendResponse(w, h.scorecardResponse(entry, review), "success", http.StatusOK)
+}
+
+func (h *handler) DeleteAdminChat(w http.ResponseWriter, req *http.Request) {
+	entry, ok := h.getChat(w, req)
+	if !ok {
+		return
+	}
+
+	h.deleteChat(w, entry)
+}
+
+func (h *handler) GetStats(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	from, err := parseTime(query.Get("from"))
+	if err != nil {
+		sendResponse(w, nil, "from must be a date (2006-01-02) or RFC 3339 time", http.StatusBadRequest)
+
+		return
+	}
+
+	to, err := parseTime(query.Get("to"))
+	if err != nil {
+		sendResponse(w, nil, "to must be a date (2006-01-02) or RFC 3339 time", http.StatusBadRequest)
+
+		return
+	}
+
+	stats, err := h.db.ChatStats(from, to)
+	if err != nil {
+		log.Printf("failed to get chat stats: %v", err)
+		sendResponse(w, nil, "failed to get chat stats", http.StatusInternalServerError)
+
+		return
+	}
+
+	response := []model.TemplateStats{}
+	for _, stat := range stats {
+		template, _ := h.template(stat.Template)
+		response = append(response, model.TemplateStats{
+			Template:       stat.Template,
+			Title:          template.Title,
+			Chats:          stat.Chats,
+			Finished:       stat.Finished,
+			Scored:         stat.Scored,
+			AverageScore:   math.Round(stat.AverageScore*100) / 100,
+			AverageAnswers: math.Round(stat.AverageAnswers*10) / 10,
+		})
+	}
+
+	sendResponse(w, response, "success", http.StatusOK)
+}
+
+// getChat digunakan untuk mengambil chat berdasarkan ID di URL untuk reviewer, admin, dan service,
+// respons error sudah dikirim jika hasilnya false
+func (h *handler) getChat(w http.ResponseWriter, req *http.Request) (data.ChatEntry, bool) {
+	entry, err := h.db.GetChat(chi.URLParam(req, "id"))
+	if errors.Is(err, data.ErrChatNotFound) {
+		sendResponse(w, nil, "chat not found", http.StatusNotFound)
+
+		return data.ChatEntry{}, false
+	}
+	if err != nil {
+		log.Printf("failed to get chat: %v", err)
+		sendResponse(w, nil, "failed to get chat", http.StatusInternalServerError)
+
+		return data.ChatEntry{}, false
+	}
+
+	return entry, true
+}
+
+// noAudioURL dipakai di transcript untuk reviewer, admin, dan service yang tidak bisa mengambil audio
+func noAudioURL(string) string {
+	return ""
+}
+
+// chatFilter digunakan untuk membaca filter daftar chat admin dari query
+func chatFilter(query url.Values) (data.ChatFilter, error) {
+	filter := data.ChatFilter{
+		Template: query.Get("template"),
+		Query:    query.Get("q"),
+		Limit:    defaultAdminPageSize,
+	}
+
+	var err error
+	if filter.From, err = parseTime(query.Get("from")); err != nil {
+		return filter, errors.New("from must be a date (2006-01-02) or RFC 3339 time")
+	}
+	if filter.To, err = parseTime(query.Get("to")); err != nil {
+		return filter, errors.New("to must be a date (2006-01-02) or RFC 3339 time")
+	}
+
+	if filter.MinScore, err = parseScore(query.Get("min_score")); err != nil {
+		return filter, errors.New("min_score must be a number between 0 and 1")
+	}
+	if filter.MaxScore, err = parseScore(query.Get("max_score")); err != nil {
+		return filter, errors.New("max_score must be a number between 0 and 1")
+	}
+
+	if finished := query.Get("finished"); finished != "" {
+		value, err := strconv.ParseBool(finished)
+		if err != nil {
+			return filter, errors.New("finished must be true or false")
+		}
+		filter.Finished = &value
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil || filter.Limit <= 0 || filter.Limit > maxAdminPageSize {
+			return filter, fmt.Errorf("limit must be between 1 and %d", maxAdminPageSize)
+		}
+	}
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		after, err := data.ParseCursor(cursor)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = &after
+	}
+
+	return filter, nil
+}
+
+// parseTime digunakan untuk membaca waktu dari query, tanggal saja dianggap pukul 00:00 UTC
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, s)
+}
+
+// parseScore digunakan untuk membaca batas nilai scorecard dari query
+func parseScore(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	score, err := strconv.ParseFloat(s, 64)
+	if err != nil || score < 0 || score > 1 {
+		return nil, errors.New("invalid score")
+	}
+
+	return &score, nil
+}
+
+// adminChatResponse digunakan untuk meringkas chat di daftar admin
+func (h *handler) adminChatResponse(entry data.ChatEntry) model.AdminChat {
+	response := model.AdminChat{
+		ID:        entry.ID,
+		Template:  entry.Template,
+		Language:  string(entry.Language),
+		Stage:     string(h.templateFor(entry).Plan.Current(entry.Progress).Stage),
+		Finished:  entry.Progress.Finished,
+		Answers:   entry.AnswerCount(),
+		CreatedAt: entry.CreatedAt,
+		UpdatedAt: entry.UpdatedAt,
+
+		FlaggedAnswers: len(entry.FlaggedAnswers()),
+	}
+
+	if entry.Scorecard != nil {
+		overall := entry.Scorecard.Overall
+		response.Overall = &overall
+		response.ScoredAnswers = entry.Scorecard.ScoredTurns
+	}
+
+	return response
+}
+
+// flagsResponse digunakan untuk mengubah tanda guard di setiap jawaban menjadi respons API
+func flagsResponse(entry data.ChatEntry) []model.AnswerFlag {
+	response := []model.AnswerFlag{}
+	for _, flagged := range entry.FlaggedAnswers() {
+		for _, flag := range flagged.Flags {
+			response = append(response, model.AnswerFlag{
+				Answer: flagged.Answer,
+				Kind:   flag.Kind,
+				Detail: flag.Detail,
+				Action: flag.Action,
+			})
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/share"
+	"github.com/go-chi/chi"
+)
+
+// signedAudioTTL adalah masa berlaku URL audio bertanda tangan
+const signedAudioTTL = 15 * time.Minute
+
+func (h *handler) GetSpeech(w http.ResponseWriter, req *http.Request) {
+	// ambil audio dari cache TTS, cache hanya berisi kalimat pembuka yang sama untuk semua user
+	key := chi.URLParam(req, "key")
+	audio, ok := h.tts.Get(key)
+	if !ok {
+		sendResponse(w, nil, "speech not found", http.StatusNotFound)
+
+		return
+	}
+
+	// audio bersifat content-addressed sehingga aman untuk di-cache browser
+	w.Header().Set("Content-Type", "audio/mpeg")
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	http.ServeContent(w, req, key+".mp3", time.Time{}, bytes.NewReader(audio))
+}
+
+func (h *handler) GetAudio(w http.ResponseWriter, req *http.Request) {
+	// ambil chat entry milik user yang sedang login
+	entry, ok := h.authorizeChat(w, req)
+	if !ok {
+		return
+	}
+
+	h.sendAudio(w, req, entry.ID, chi.URLParam(req, "id"))
+}
+
+func (h *handler) GetSignedAudio(w http.ResponseWriter, req *http.Request) {
+	// token berisi ID chat dan ID blob audio sehingga tidak membutuhkan kata sandi sesi
+	claims, err := h.audioLinks.Verify(chi.URLParam(req, "token"), time.Now())
+	if err != nil {
+		sendResponse(w, nil, "audio not found", http.StatusNotFound)
+
+		return
+	}
+
+	h.sendAudio(w, req, claims.ChatID, claims.ShareID)
+}
+
+// sendAudio digunakan untuk mengirim audio dari blob store, audio chat lain dianggap tidak ada
+func (h *handler) sendAudio(w http.ResponseWriter, req *http.Request, chatID, id string) {
+	// ambil audio dari blob store
+	blob, err := h.blobs.GetBlob(id)
+	if errors.Is(err, data.ErrBlobNotFound) {
+		sendResponse(w, nil, "audio not found", http.StatusNotFound)
+
+		return
+	}
+	if err != nil {
+		log.Printf("failed to get audio: %v", err)
+		sendResponse(w, nil, "failed to get audio", http.StatusInternalServerError)
+
+		return
+	}
+
+	// audio hanya bisa diambil oleh pemilik chat
+	if blob.ChatID != chatID {
+		sendResponse(w, nil, "audio not found", http.StatusNotFound)
+
+		return
+	}
+
+	// ServeContent menangani header Range untuk streaming
+	w.Header().Set("Content-Type", blob.ContentType)
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	http.ServeContent(w, req, blob.ID, blob.CreatedAt, bytes.NewReader(blob.Data))
+}
+
+// speechURL digunakan untuk membuat URL audio TTS dari key cache
+func speechURL(key string) string {
+	return path.Join("/chat/tts", key)
+}
+
+// audioURL digunakan untuk membuat URL audio dari ID blob
+func audioURL(id string) string {
+	return path.Join("/chat/audio", id)
+}
+
+// signedAudioURL digunakan untuk membuat URL audio yang berlaku sebentar tanpa header Authorization,
+// sehingga browser bisa memutarnya langsung dengan request Range
+func (h *handler) signedAudioURL(chatID, id string) string {
+	token := h.audioLinks.Sign(share.Claims{
+		ChatID:    chatID,
+		ShareID:   id,
+		ExpiresAt: time.Now().Add(signedAudioTTL),
+	})
+
+	return path.Join("/audio", token)
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+)
+
+// authorizeChat digunakan untuk mengambil chat entry dan memvalidasi kata sandi user,
+// respons error sudah dikirim jika hasilnya false
+func (h *handler) authorizeChat(w http.ResponseWriter, req *http.Request) (data.ChatEntry, bool) {
+	// ambil user ID dan kata sandi dari konteks (diatur oleh middleware)
+	userID, _ := req.Context().Value(contextKeyUserID).(string)
+	userSecret, _ := req.Context().Value(contextKeyUserSecret).(string)
+
+	// pastikan user ID dan kata sandi tidak kosong
+	if userID == "" || userSecret == "" {
+		log.Println("user ID or secret is missing")
+		sendResponse(w, nil, "missing required authentication", http.StatusUnauthorized)
+
+		return data.ChatEntry{}, false
+	}
+
+	// ambil chat entry berdasarkan user ID
+	entry, err := h.db.GetChat(userID)
+	if errors.Is(err, data.ErrChatNotFound) {
+		log.Println("chat not found")
+		sendResponse(w, nil, "invalid user secret", http.StatusUnauthorized)
+
+		return data.ChatEntry{}, false
+	}
+	if err != nil {
+		log.Printf("failed to get chat: %v", err)
+		sendResponse(w, nil, "failed to get chat", http.StatusInternalServerError)
+
+		return data.ChatEntry{}, false
+	}
+
+	// bandingkan kata sandi
+	if err := compareHash(userSecret, entry.Secret); err != nil {
+		log.Println("invalid user secret")
+		sendResponse(w, nil, "invalid user secret", http.StatusUnauthorized)
+
+		return data.ChatEntry{}, false
+	}
+
+	return entry, true
+}
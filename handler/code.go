@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+	"github.com/fastcampus-backend-golang/ai-interview/redact"
+	"github.com/fastcampus-backend-golang/ai-interview/sandbox"
+)
+
+// maxCodeSize adalah ukuran maksimal body request kode dari user
+const maxCodeSize = 64 << 10
+
+const (
+	// maxSandboxRuns adalah jumlah maksimal kode user yang dikompilasi dan dijalankan bersamaan
+	maxSandboxRuns = 2
+	// sandboxQueueTimeout adalah lama maksimal kode menunggu giliran dijalankan
+	sandboxQueueTimeout = 30 * time.Second
+)
+
+// submissionInstruction memberi tahu AI bahwa giliran berikutnya berisi kode dan hasil test
+const submissionInstruction = "The interviewee submitted code for the coding problem. Their next message contains the code and the result of running it against hidden tests. Discuss the solution and the test results with them, for example failing cases, complexity, and edge cases, without writing the full solution for them."
+
+func (h *handler) SubmitCode(w http.ResponseWriter, req *http.Request) {
+	// ambil chat entry milik user yang sedang login
+	entry, ok := h.authorizeChat(w, req)
+	if !ok {
+		return
+	}
+
+	// interview yang sudah selesai tidak menerima jawaban baru
+	if entry.Progress.Finished {
+		sendResponse(w, nil, "interview has finished", http.StatusConflict)
+
+		return
+	}
+
+	// sandbox tidak tersedia jika gagal dibuat saat server dijalankan
+	if h.sandbox == nil {
+		sendResponse(w, nil, "code execution is not available", http.StatusServiceUnavailable)
+
+		return
+	}
+
+	var body model.SubmitCodeRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxCodeSize)).Decode(&body); err != nil {
+		log.Printf("failed to read code: %v", err)
+		sendResponse(w, nil, "invalid request body", http.StatusBadRequest)
+
+		return
+	}
+	if strings.TrimSpace(body.Code) == "" {
+		sendResponse(w, nil, "code is required", http.StatusBadRequest)
+
+		return
+	}
+
+	// kode dijalankan terhadap test soal coding terakhir yang diberikan interviewer
+	question, ok := entry.Questions.LastCoding()
+	if !ok {
+		sendResponse(w, nil, "no coding problem has been given", http.StatusConflict)
+
+		return
+	}
+
+	problem, ok := h.questions[question.ID]
+	if !ok || !problem.IsCoding() {
+		log.Printf("coding problem not found: %s", question.ID)
+		sendResponse(w, nil, "coding problem is no longer available", http.StatusInternalServerError)
+
+		return
+	}
+
+	// setiap run memakai CPU dan memori penuh, kode menunggu giliran jika sudah ada maxSandboxRuns yang berjalan
+	queue := time.NewTimer(sandboxQueueTimeout)
+	defer queue.Stop()
+
+	select {
+	case h.sandboxRuns <- struct{}{}:
+	case <-queue.C:
+		sendResponse(w, nil, "too many code submissions are running, please try again", http.StatusServiceUnavailable)
+
+		return
+	case <-req.Context().Done():
+		return
+	}
+
+	submittedAt := time.Now()
+	result, err := h.sandbox.Run(body.Code, problem.Tests)
+	<-h.sandboxRuns
+	if err != nil {
+		log.Printf("failed to run code: %v", err)
+		sendResponse(w, nil, "failed to run code", http.StatusInternalServerError)
+
+		return
+	}
+
+	// samarkan data pribadi di kode dan output sebelum dikirim ke AI dan disimpan
+	code, redactions := h.redactor.Redact(body.Code, entry.ID)
+	output, outputRedactions := h.redactor.Redact(result.Output, entry.ID)
+	for placeholder, value := range outputRedactions {
+		redactions[placeholder] = value
+	}
+
+	submission := data.Submission{
+		QuestionID: question.ID,
+		Code:       code,
+		Status:     result.Status,
+		Tests:      result.Tests,
+		Output:     output,
+		Duration:   result.Duration,
+	}
+
+	userTurn := data.Turn{
+		Role:       ai.ROLE_USER,
+		Content:    submissionMessage(question, submission),
+		CreatedAt:  submittedAt,
+		Stage:      h.templateFor(entry).Plan.Current(entry.Progress).Stage,
+		QuestionID: question.ID,
+		Submission: &submission,
+	}
+
+	entry, userTurn, assistantTurn, ok := h.respond(w, entry, answer{
+		turns: []data.Turn{data.NewTurn(ai.ChatMessage{
+			Role:    ai.ROLE_SYSTEM,
+			Content: submissionInstruction,
+		})},
+		user:       userTurn,
+		redactions: redactions,
+	})
+	if !ok {
+		return
+	}
+
+	// kirim respons
+	response := model.SubmitCodeResponse{
+		Stage:      string(h.templateFor(entry).Plan.Current(entry.Progress).Stage),
+		Finished:   entry.Progress.Finished,
+		Submission: submissionResponse(*userTurn.Submission, entry.Redactions),
+		Answer: model.Chat{
+			Text:     redact.Restore(assistantTurn.Content, entry.Redactions),
+			AudioURL: h.signedAudioURL(entry.ID, assistantTurn.Audio.BlobID),
+			Speaker:  speakerResponse(h.templateFor(entry).Panel, assistantTurn.Speaker),
+		},
+		Problem: codingProblem(entry, assistantTurn),
+	}
+
+	sendResponse(w, response, "success", http.StatusOK)
+}
+
+// submissionMessage digunakan untuk membuat pesan berisi kode dan hasil test untuk AI
+func submissionMessage(question interview.SessionQuestion, submission data.Submission) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "My solution for the coding problem %q:\n\n```go\n%s\n```\n\n", question.Text, submission.Code)
+
+	passed := 0
+	for _, test := range submission.Tests {
+		if test.Passed {
+			passed++
+		}
+	}
+	fmt.Fprintf(&b, "Hidden test result: %s, %d of %d tests passed.\n", submission.Status, passed, len(submission.Tests))
+
+	for _, test := range submission.Tests {
+		status := "FAIL"
+		if test.Passed {
+			status = "PASS"
+		}
+		fmt.Fprintf(&b, "- %s: %s\n", test.Name, status)
+	}
+
+	if submission.Status != sandbox.STATUS_PASSED && submission.Output != "" {
+		fmt.Fprintf(&b, "\nOutput:\n```\n%s\n```\n", submission.Output)
+	}
+
+	return b.String()
+}
+
+// submissionResponse digunakan untuk mengubah submission menjadi respons API
+func submissionResponse(submission data.Submission, redactions redact.Mapping) model.Submission {
+	response := model.Submission{
+		QuestionID: submission.QuestionID,
+		Status:     string(submission.Status),
+		Total:      len(submission.Tests),
+		Tests:      []model.TestResult{},
+		Output:     redact.Restore(submission.Output, redactions),
+	}
+
+	for _, test := range submission.Tests {
+		if test.Passed {
+			response.Passed++
+		}
+		response.Tests = append(response.Tests, model.TestResult{
+			Name:   test.Name,
+			Passed: test.Passed,
+		})
+	}
+
+	return response
+}
+
+// codingProblem digunakan untuk mendapatkan soal coding yang diberikan di giliran interviewer
+func codingProblem(entry data.ChatEntry, turn data.Turn) *model.CodingProblem {
+	if turn.QuestionID == "" {
+		return nil
+	}
+
+	question, ok := entry.Questions.Get(turn.QuestionID)
+	if !ok || !question.Coding {
+		return nil
+	}
+
+	return &model.CodingProblem{
+		QuestionID:  question.ID,
+		Text:        question.Text,
+		StarterCode: question.StarterCode,
+	}
+}
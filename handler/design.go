@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/design"
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+	"github.com/fastcampus-backend-golang/ai-interview/redact"
+)
+
+// maxDesignSize adalah ukuran maksimal body request desain dari user
+const maxDesignSize = 64 << 10
+
+// designInstruction memberi tahu AI bahwa giliran berikutnya berisi desain sistem
+const designInstruction = "The interviewee submitted a structured system design. Their next message contains the components, data stores, APIs, and diagram. Together with what they said, probe the design with follow-up questions about scaling (bottlenecks, caching, partitioning, load growth) and failure modes (component outages, data loss, retries, consistency). Ask one question at a time."
+
+func (h *handler) SubmitDesign(w http.ResponseWriter, req *http.Request) {
+	// ambil chat entry milik user yang sedang login
+	entry, ok := h.authorizeChat(w, req)
+	if !ok {
+		return
+	}
+
+	// interview yang sudah selesai tidak menerima jawaban baru
+	if entry.Progress.Finished {
+		sendResponse(w, nil, "interview has finished", http.StatusConflict)
+
+		return
+	}
+
+	// desain hanya diterima di tahap system design
+	plan := h.templateFor(entry).Plan
+	if plan.Current(entry.Progress).Stage != interview.STAGE_SYSTEM_DESIGN {
+		sendResponse(w, nil, "system design stage has not started", http.StatusConflict)
+
+		return
+	}
+
+	var body model.SubmitDesignRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxDesignSize)).Decode(&body); err != nil {
+		log.Printf("failed to read design: %v", err)
+		sendResponse(w, nil, "invalid request body", http.StatusBadRequest)
+
+		return
+	}
+
+	submitted := designFromModel(body)
+	if err := submitted.Parse(); err != nil {
+		sendResponse(w, nil, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	// samarkan data pribadi sebelum desain dikirim ke AI
+	summary, redactions := h.redactor.Redact(submitted.Summary(), entry.ID)
+
+	userTurn := data.Turn{
+		Role:       ai.ROLE_USER,
+		Content:    "My system design:\n\n" + summary,
+		CreatedAt:  time.Now(),
+		Stage:      plan.Current(entry.Progress).Stage,
+		QuestionID: lastQuestionID(entry),
+		Design:     &submitted,
+	}
+
+	entry, userTurn, assistantTurn, ok := h.respond(w, entry, answer{
+		turns: []data.Turn{data.NewTurn(ai.ChatMessage{
+			Role:    ai.ROLE_SYSTEM,
+			Content: designInstruction,
+		})},
+		user:       userTurn,
+		redactions: redactions,
+	})
+	if !ok {
+		return
+	}
+
+	// kirim respons
+	response := model.SubmitDesignResponse{
+		Stage:    string(plan.Current(entry.Progress).Stage),
+		Finished: entry.Progress.Finished,
+		Design:   designResponse(*userTurn.Design, userTurn.CreatedAt),
+		Answer: model.Chat{
+			Text:     redact.Restore(assistantTurn.Content, entry.Redactions),
+			AudioURL: h.signedAudioURL(entry.ID, assistantTurn.Audio.BlobID),
+			Speaker:  speakerResponse(h.templateFor(entry).Panel, assistantTurn.Speaker),
+		},
+	}
+
+	sendResponse(w, response, "success", http.StatusOK)
+}
+
+func (h *handler) GetDesign(w http.ResponseWriter, req *http.Request) {
+	// ambil chat entry milik user yang sedang login
+	entry, ok := h.authorizeChat(w, req)
+	if !ok {
+		return
+	}
+
+	latest, submittedAt, ok := entry.LatestDesign()
+	if !ok {
+		sendResponse(w, nil, "design not found", http.StatusNotFound)
+
+		return
+	}
+
+	sendResponse(w, designResponse(latest, submittedAt), "success", http.StatusOK)
+}
+
+// designFromModel digunakan untuk mengubah request API menjadi desain
+func designFromModel(body model.Design) design.Design {
+	result := design.Design{
+		Diagram: design.Diagram{
+			Format: design.Format(body.Diagram.Format),
+			Source: body.Diagram.Source,
+		},
+		Notes: body.Notes,
+	}
+
+	for _, component := range body.Components {
+		result.Components = append(result.Components, design.Component{
+			Name:           component.Name,
+			Responsibility: component.Responsibility,
+		})
+	}
+	for _, store := range body.DataStores {
+		result.DataStores = append(result.DataStores, design.DataStore{
+			Name:    store.Name,
+			Kind:    store.Kind,
+			Purpose: store.Purpose,
+		})
+	}
+	for _, api := range body.APIs {
+		result.APIs = append(result.APIs, design.API{
+			Method:      api.Method,
+			Path:        api.Path,
+			Description: api.Description,
+		})
+	}
+
+	return result
+}
+
+// designResponse digunakan untuk mengubah desain menjadi respons API
+func designResponse(d design.Design, submittedAt time.Time) model.Design {
+	response := model.Design{
+		Components: []model.DesignComponent{},
+		DataStores: []model.DesignDataStore{},
+		APIs:       []model.DesignAPI{},
+		Diagram: model.DesignDiagram{
+			Format: string(d.Diagram.Format),
+			Source: d.Diagram.Source,
+		},
+		Notes:       d.Notes,
+		SubmittedAt: submittedAt,
+		Summary:     d.Summary(),
+	}
+
+	for _, component := range d.Components {
+		response.Components = append(response.Components, model.DesignComponent{
+			Name:           component.Name,
+			Responsibility: component.Responsibility,
+		})
+	}
+	for _, store := range d.DataStores {
+		response.DataStores = append(response.DataStores, model.DesignDataStore{
+			Name:    store.Name,
+			Kind:    store.Kind,
+			Purpose: store.Purpose,
+		})
+	}
+	for _, api := range d.APIs {
+		response.APIs = append(response.APIs, model.DesignAPI{
+			Method:      api.Method,
+			Path:        api.Path,
+			Description: api.Description,
+		})
+	}
+	for _, edge := range d.Edges {
+		response.Edges = append(response.Edges, model.DesignEdge{
+			From:  edge.From,
+			To:    edge.To,
+			Label: edge.Label,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/export"
+	"github.com/fastcampus-backend-golang/ai-interview/guard"
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+	"github.com/fastcampus-backend-golang/ai-interview/redact"
+)
+
+func (h *handler) ExportChat(w http.ResponseWriter, req *http.Request) {
+	// ambil chat entry milik user yang sedang login
+	entry, ok := h.authorizeChat(w, req)
+	if !ok {
+		return
+	}
+
+	h.sendExport(w, req, entry, audioURL, true)
+}
+
+// sendExport digunakan untuk mengirim transcript chat sebagai file sesuai format di query,
+// audio berisi fungsi untuk membuat URL audio dari ID blob dan rawAnswers diteruskan ke transcript
+func (h *handler) sendExport(w http.ResponseWriter, req *http.Request, entry data.ChatEntry, audio func(string) string, rawAnswers bool) {
+	format, ok := export.ParseFormat(req.URL.Query().Get("format"))
+	if !ok {
+		sendResponse(w, nil, "format must be one of md, html, pdf, or json", http.StatusBadRequest)
+
+		return
+	}
+
+	review, ok := h.reviewFor(w, req, entry.ID)
+	if !ok {
+		return
+	}
+
+	document, err := export.Render(format, h.transcript(entry, time.Now(), audio, review, rawAnswers))
+	if err != nil {
+		log.Printf("failed to export chat: %v", err)
+		sendResponse(w, nil, "failed to export chat", http.StatusInternalServerError)
+
+		return
+	}
+
+	// kirim sebagai file yang bisa diunduh
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="interview-%s.%s"`, entry.ID, format))
+	w.Write(document)
+}
+
+// transcript digunakan untuk menyusun isi export dari history chat tanpa pesan system,
+// data pribadi yang disamarkan dikembalikan karena export hanya diberikan ke pemilik chat, penerima link share, atau reviewer.
+// Komentar dan override dari review disertakan jika review tidak kosong.
+// Jawaban user ditampilkan tanpa pembungkus guard, dan rawAnswers berarti hasil transkripsi mentah dipakai jika ada
+// sehingga pemilik chat dan reviewer tetap bisa membaca jawaban lisan yang ditolak guard
+func (h *handler) transcript(entry data.ChatEntry, exportedAt time.Time, audio func(string) string, review *data.Review, rawAnswers bool) model.Transcript {
+	template := h.templateFor(entry)
+
+	transcript := model.Transcript{
+		ID:         entry.ID,
+		Template:   template.Name,
+		Title:      template.Title,
+		Language:   string(entry.Language),
+		Stage:      string(template.Plan.Current(entry.Progress).Stage),
+		Finished:   entry.Progress.Finished,
+		StartedAt:  entry.CreatedAt,
+		ExportedAt: exportedAt,
+		Messages:   []model.TranscriptMessage{},
+		Scorecard:  h.scorecardResponse(entry, review),
+	}
+
+	answerNumber := 0
+	for _, t := range entry.History {
+		if t.Role == ai.ROLE_SYSTEM {
+			continue
+		}
+
+		message := model.TranscriptMessage{
+			Role:      string(t.Role),
+			Stage:     string(t.Stage),
+			Text:      redact.Restore(t.Content, entry.Redactions),
+			CreatedAt: t.CreatedAt,
+		}
+		if t.Role == ai.ROLE_USER {
+			answerNumber++
+			message.Answer = answerNumber
+			message.Text = guard.Unwrap(message.Text)
+			if rawAnswers && t.Transcript != "" {
+				message.Text = t.Transcript
+			}
+
+			if review != nil {
+				for _, comment := range review.Comments {
+					if comment.Turn == answerNumber {
+						message.Comments = append(message.Comments, reviewCommentResponse(comment))
+					}
+				}
+			}
+		} else {
+			message.Speaker = speakerResponse(template.Panel, t.Speaker)
+		}
+
+		// rekaman jawaban hanya disertakan selama belum melewati masa simpan
+		switch {
+		case t.Audio != nil:
+			message.AudioURL = audio(t.Audio.BlobID)
+		case t.Recording != nil && t.Recording.ExpiresAt.After(exportedAt):
+			message.AudioURL = audio(t.Recording.BlobID)
+		}
+
+		transcript.Messages = append(transcript.Messages, message)
+	}
+
+	return transcript
+}
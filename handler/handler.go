@@ -1,30 +1,270 @@
 package handler
 
 import (
-	"encoding/base64"
+	"crypto/rand"
+	"errors"
 	"io"
 	"log"
 	"net/http"
 	"path"
+	"sync"
+	"time"
 
+	"github.com/fastcampus-backend-golang/ai-interview/access"
 	"github.com/fastcampus-backend-golang/ai-interview/ai"
 	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/guard"
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
 	"github.com/fastcampus-backend-golang/ai-interview/model"
+	"github.com/fastcampus-backend-golang/ai-interview/redact"
+	"github.com/fastcampus-backend-golang/ai-interview/sandbox"
+	"github.com/fastcampus-backend-golang/ai-interview/share"
+	"github.com/fastcampus-backend-golang/ai-interview/speech"
+	"github.com/fastcampus-backend-golang/ai-interview/tts"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/cors"
 )
 
 type handler struct {
-	ai ai.Client
-	db data.Client
+	ai                 ai.Client
+	db                 data.Client
+	blobs              data.BlobStore
+	tts                *tts.Synthesizer
+	questions          interview.Bank
+	accounts           data.AccountStore
+	shareAccesses      data.ShareAccessStore
+	reviews            data.ReviewStore
+	admins             adminTokens
+	sandbox            *sandbox.Runner
+	redactor           *redact.Redactor
+	guard              *guard.Guard
+	shares             *share.Signer
+	audioLinks         *share.Signer
+	chatTTL            time.Duration
+	recordingRetention time.Duration
+
+	// tasks membatasi jumlah penilaian dan analisis jawaban yang berjalan bersamaan di latar belakang
+	tasks chan struct{}
+	// sandboxRuns membatasi jumlah kode user yang dikompilasi dan dijalankan bersamaan
+	sandboxRuns chan struct{}
+
+	// templates bisa dibaca ulang dari templateDir oleh admin saat server berjalan
+	templatesMu sync.RWMutex
+	templates   interview.Templates
+	templateDir string
 }
 
-func NewHandler(apiKey string, dbURI string) *chi.Mux {
+// Config berisi konfigurasi yang dibutuhkan handler
+type Config struct {
+	APIKey       string
+	DBDriver     string
+	DBURI        string
+	TTSCacheDir  string
+	TTSCacheSize int
+	BlobStore    string
+	BlobDir      string
+	TemplateDir  string
+	QuestionDir  string
+
+	// SandboxGo adalah toolchain Go untuk menjalankan kode user di SandboxCacheDir,
+	// SandboxLimits berisi batas waktu, CPU, dan memorinya
+	SandboxGo       string
+	SandboxCacheDir string
+	SandboxLimits   sandbox.Limits
+
+	// EncryptionKeys berisi kunci enkripsi dengan format "id:base64,id:base64",
+	// kunci pertama dipakai untuk enkripsi baru
+	EncryptionKeys string
+
+	// AdminTokens berisi token admin dengan format "nama:token,nama:token",
+	// akun reviewer, admin, dan service lainnya dibuat oleh admin melalui API
+	AdminTokens string
+
+	// ShareKey adalah kunci rahasia untuk menandatangani token link share,
+	// kunci acak dipakai jika kosong sehingga link tidak berlaku lagi setelah server restart
+	ShareKey string
+
+	// Redaction berisi jenis data pribadi yang disamarkan sebelum dikirim ke AI
+	Redaction []string
+
+	// GuardModerator adalah moderator jawaban (openai, local, atau none),
+	// GuardInjectionAction menentukan apakah prompt injection dinetralkan atau ditolak
+	GuardModerator       string
+	GuardInjectionAction string
+
+	// ChatTTL adalah lama chat disimpan sejak terakhir diubah, 0 berarti tanpa batas
+	ChatTTL time.Duration
+
+	// RecordingRetention adalah lama rekaman jawaban disimpan sebelum dihapus
+	RecordingRetention time.Duration
+}
+
+const (
+	DB_DRIVER_MONGO  = "mongo"
+	DB_DRIVER_MEMORY = "memory"
+
+	BLOB_STORE_FS     = "fs"
+	BLOB_STORE_GRIDFS = "gridfs"
+
+	MODERATOR_OPENAI = "openai"
+	MODERATOR_LOCAL  = "local"
+	MODERATOR_NONE   = "none"
+)
+
+// withheldAnswer menggantikan jawaban yang ditolak guard di history yang dikirim ke AI
+const withheldAnswer = "[answer withheld by moderation]"
+
+func NewHandler(cfg Config) *chi.Mux {
+	openAI := ai.NewOpenAI(cfg.APIKey)
+
+	// buat cache untuk audio TTS
+	cache, err := tts.NewCache(cfg.TTSCacheSize, cfg.TTSCacheDir)
+	if err != nil {
+		log.Fatalf("failed to create TTS cache: %v", err)
+	}
+
+	// buat penyimpanan chat
+	var db data.Client
+	var accounts data.AccountStore
+	var shareAccesses data.ShareAccessStore
+	var reviews data.ReviewStore
+	var mongo *data.Mongo
+	switch cfg.DBDriver {
+	case DB_DRIVER_MEMORY:
+		memory := data.NewMemory()
+		db = memory
+		accounts = memory
+		shareAccesses = memory
+		reviews = memory
+	default:
+		mongo = data.NewMongo(cfg.DBURI)
+		db = mongo
+		accounts = mongo
+		shareAccesses = mongo
+		reviews = mongo
+	}
+
+	// buat penyimpanan audio, GridFS membutuhkan MongoDB
+	var blobs data.BlobStore
+	switch {
+	case cfg.BlobStore == BLOB_STORE_FS:
+		blobs, err = data.NewFileSystem(cfg.BlobDir)
+	case mongo != nil:
+		blobs, err = data.NewGridFS(mongo)
+	default:
+		log.Fatal("GridFS blob store requires the mongo driver")
+	}
+	if err != nil {
+		log.Fatalf("failed to create blob store: %v", err)
+	}
+
+	// enkripsi history, audio, dan review sebelum disimpan
+	if cfg.EncryptionKeys != "" {
+		keyring, err := data.ParseKeyring(cfg.EncryptionKeys)
+		if err != nil {
+			log.Fatalf("failed to parse encryption keys: %v", err)
+		}
+
+		db = data.NewEncrypted(db, keyring)
+		blobs = data.NewEncryptedBlobStore(blobs, keyring)
+		reviews = data.NewEncryptedReviewStore(reviews, keyring)
+	} else {
+		log.Println("warning: ENCRYPTION_KEYS is not set, chats, audio, and reviews are stored unencrypted")
+	}
+
+	// baca bank soal
+	questions, err := interview.LoadBank(cfg.QuestionDir)
+	if err != nil {
+		log.Fatalf("failed to load question bank: %v", err)
+	}
+
+	// buat sandbox untuk menjalankan kode jawaban soal coding,
+	// server tetap berjalan tanpa tahap coding jika sandbox tidak bisa dibuat
+	runner, err := sandbox.NewRunner(cfg.SandboxGo, cfg.SandboxCacheDir, cfg.SandboxLimits)
+	if err != nil {
+		log.Printf("warning: failed to create sandbox, coding stage is disabled: %v", err)
+		runner = nil
+	}
+
+	// baca semua template interview dan pastikan pertanyaan wajib di setiap template tersedia
+	templates, err := loadTemplates(cfg.TemplateDir, questions, runner != nil)
+	if err != nil {
+		log.Fatalf("failed to load templates: %v", err)
+	}
+
+	// buat redactor untuk menyamarkan data pribadi
+	redactor, err := redact.New(cfg.Redaction)
+	if err != nil {
+		log.Fatalf("failed to create redactor: %v", err)
+	}
+
+	// buat guard untuk mengecek jawaban user
+	var moderator guard.Moderator
+	switch cfg.GuardModerator {
+	case MODERATOR_OPENAI:
+		moderator = guard.NewOpenAIModerator(openAI)
+	case MODERATOR_LOCAL:
+		moderator = guard.NewLocalModerator()
+	}
+
+	answerGuard, err := guard.NewGuard(moderator, guard.Action(cfg.GuardInjectionAction))
+	if err != nil {
+		log.Fatalf("failed to create guard: %v", err)
+	}
+
+	// baca token admin dari konfigurasi, akun lain dibuat oleh admin melalui API
+	admins, err := parseAdminTokens(cfg.AdminTokens)
+	if err != nil {
+		log.Fatalf("failed to parse admin tokens: %v", err)
+	}
+
+	// buat signer untuk token link share
+	shareKey := []byte(cfg.ShareKey)
+	if cfg.ShareKey == "" {
+		log.Println("warning: SHARE_KEY is not set, share links stop working when the server restarts")
+
+		shareKey = make([]byte, share.MIN_KEY_SIZE)
+		if _, err := rand.Read(shareKey); err != nil {
+			log.Fatalf("failed to generate share key: %v", err)
+		}
+	}
+
+	shares, err := share.NewSigner(shareKey)
+	if err != nil {
+		log.Fatalf("failed to create share signer: %v", err)
+	}
+
 	h := &handler{
-		ai: ai.NewOpenAI(apiKey),
-		db: data.NewMongo(dbURI),
+		ai:                 openAI,
+		db:                 db,
+		blobs:              blobs,
+		tts:                tts.NewSynthesizer(openAI, openAI.TTSProvider(), openAI.TTSVoice, cache),
+		questions:          questions,
+		accounts:           accounts,
+		shareAccesses:      shareAccesses,
+		reviews:            reviews,
+		admins:             admins,
+		sandbox:            runner,
+		redactor:           redactor,
+		guard:              answerGuard,
+		shares:             shares,
+		audioLinks:         shares.Derive("audio"),
+		chatTTL:            cfg.ChatTTL,
+		recordingRetention: cfg.RecordingRetention,
+		templates:          templates,
+		templateDir:        cfg.TemplateDir,
+		tasks:              make(chan struct{}, maxBackgroundTasks),
+		sandboxRuns:        make(chan struct{}, maxSandboxRuns),
 	}
 
+	// hapus chat dan rekaman yang sudah melewati masa simpan secara berkala
+	go h.sweep(sweepInterval)
+
+	return h.routes()
+}
+
+// routes digunakan untuk membuat router berisi semua rute API beserta middleware autentikasinya
+func (h *handler) routes() *chi.Mux {
 	r := chi.NewRouter()
 
 	// gunakan middleware CORS
@@ -32,6 +272,8 @@ func NewHandler(apiKey string, dbURI string) *chi.Mux {
 		AllowedOrigins: []string{"*"},
 		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "HEAD"},
 		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type"},
+		// nama file export dibaca frontend dari header Content-Disposition
+		ExposedHeaders: []string{"Content-Disposition"},
 	}))
 
 	// sajikan direktori static ke /public
@@ -43,10 +285,56 @@ func NewHandler(apiKey string, dbURI string) *chi.Mux {
 
 	// rute untuk chat
 	r.Get("/chat/start", h.StartChat)
+	r.Get("/chat/tts/{key}", h.GetSpeech)
+
+	// rute untuk audio interviewer dengan URL bertanda tangan agar bisa diputar langsung oleh <audio>
+	r.Get("/audio/{token}", h.GetSignedAudio)
 
+	// rute untuk reviewer, admin, dan service dengan token, setiap rute membutuhkan izin dari peran pemilik token
+	r.Group(func(r chi.Router) {
+		r.Use(h.tokenMiddleware)
+		r.With(requirePermission(access.PERMISSION_LIST_CHATS)).Get("/admin/chats", h.ListChats)
+		r.With(requirePermission(access.PERMISSION_READ_TRANSCRIPT)).Get("/admin/chats/{id}", h.GetAdminChat)
+		r.With(requirePermission(access.PERMISSION_READ_TRANSCRIPT)).Get("/admin/chats/{id}/export", h.ExportAdminChat)
+		r.With(requirePermission(access.PERMISSION_READ_SCORE)).Get("/admin/chats/{id}/scores", h.GetAdminScores)
+		r.With(requirePermission(access.PERMISSION_DELETE_CHAT)).Delete("/admin/chats/{id}", h.DeleteAdminChat)
+		r.With(requirePermission(access.PERMISSION_READ_REVIEW)).Get("/admin/chats/{id}/review", h.GetReview)
+		r.With(requirePermission(access.PERMISSION_WRITE_REVIEW)).Put("/admin/chats/{id}/review", h.UpdateReview)
+		r.With(requirePermission(access.PERMISSION_WRITE_REVIEW)).Post("/admin/chats/{id}/review/comments", h.AddReviewComment)
+		r.With(requirePermission(access.PERMISSION_WRITE_REVIEW)).Delete("/admin/chats/{id}/review/comments/{comment}", h.DeleteReviewComment)
+		r.With(requirePermission(access.PERMISSION_WRITE_REVIEW)).Put("/admin/chats/{id}/review/scores/{competency}", h.OverrideScore)
+		r.With(requirePermission(access.PERMISSION_WRITE_REVIEW)).Delete("/admin/chats/{id}/review/scores/{competency}", h.RemoveScoreOverride)
+		r.With(requirePermission(access.PERMISSION_READ_STATS)).Get("/admin/stats", h.GetStats)
+		r.With(requirePermission(access.PERMISSION_MANAGE_TEMPLATES)).Get("/admin/templates", h.ListTemplates)
+		r.With(requirePermission(access.PERMISSION_MANAGE_TEMPLATES)).Post("/admin/templates/reload", h.ReloadTemplates)
+		r.With(requirePermission(access.PERMISSION_MANAGE_ACCOUNTS)).Get("/admin/accounts", h.ListAccounts)
+		r.With(requirePermission(access.PERMISSION_MANAGE_ACCOUNTS)).Post("/admin/accounts", h.CreateAccount)
+		r.With(requirePermission(access.PERMISSION_MANAGE_ACCOUNTS)).Delete("/admin/accounts/{id}", h.DeleteAccount)
+	})
+
+	// rute untuk link share, hanya bisa membaca chat
+	r.Get("/shared/{token}", h.GetShared)
+	r.Get("/shared/{token}/export", h.ExportShared)
+	r.Get("/shared/{token}/audio/{id}", h.GetSharedAudio)
+
+	// rute untuk kandidat dengan ID dan kata sandi sesi interview, kandidat hanya bisa mengakses chat miliknya
+	// dan setiap rute membutuhkan izin dari peran kandidat
 	r.Group(func(r chi.Router) {
 		r.Use(authMiddleware)
-		r.Post("/chat/answer", h.AnswerChat)
+		r.With(requirePermission(access.PERMISSION_TAKE_INTERVIEW)).Post("/chat/answer", h.AnswerChat)
+		r.With(requirePermission(access.PERMISSION_TAKE_INTERVIEW)).Post("/chat/code", h.SubmitCode)
+		r.With(requirePermission(access.PERMISSION_TAKE_INTERVIEW)).Post("/chat/design", h.SubmitDesign)
+		r.With(requirePermission(access.PERMISSION_READ_TRANSCRIPT)).Get("/chat/design", h.GetDesign)
+		r.With(requirePermission(access.PERMISSION_READ_SCORE)).Get("/chat/scores", h.GetScores)
+		r.With(requirePermission(access.PERMISSION_READ_SCORE)).Get("/chat/answers/{turn}/ideal", h.GetIdealAnswer)
+		r.With(requirePermission(access.PERMISSION_READ_TRANSCRIPT)).Get("/chat/export", h.ExportChat)
+		r.With(requirePermission(access.PERMISSION_SHARE_TRANSCRIPT)).Post("/chat/shares", h.CreateShare)
+		r.With(requirePermission(access.PERMISSION_SHARE_TRANSCRIPT)).Get("/chat/shares", h.ListShares)
+		r.With(requirePermission(access.PERMISSION_SHARE_TRANSCRIPT)).Delete("/chat/shares/{id}", h.RevokeShare)
+		r.With(requirePermission(access.PERMISSION_DELETE_CHAT)).Delete("/chat", h.DeleteChat)
+		r.With(requirePermission(access.PERMISSION_READ_TRANSCRIPT)).Get("/chat/audio/{id}", h.GetAudio)
+		r.With(requirePermission(access.PERMISSION_READ_TRANSCRIPT)).Get("/chat/recordings", h.ListRecordings)
+		r.With(requirePermission(access.PERMISSION_READ_TRANSCRIPT)).Get("/chat/recordings/{turn}", h.GetRecording)
 	})
 
 	return r
@@ -59,8 +347,29 @@ func (h *handler) Homepage(w http.ResponseWriter, req *http.Request) {
 }
 
 func (h *handler) StartChat(w http.ResponseWriter, req *http.Request) {
+	// ambil bahasa dari query, gunakan bahasa default jika kosong
+	language, err := ai.ParseLanguage(req.URL.Query().Get("language"))
+	if err != nil {
+		log.Printf("invalid language: %v", err)
+		sendResponse(w, nil, "unsupported language", http.StatusBadRequest)
+
+		return
+	}
+
+	// ambil template interview, gunakan template default jika kosong
+	template, ok := h.template(req.URL.Query().Get("template"))
+	if !ok {
+		log.Printf("template not found: %s", req.URL.Query().Get("template"))
+		sendResponse(w, nil, "template not found", http.StatusBadRequest)
+
+		return
+	}
+
+	// rekaman jawaban hanya disimpan jika user memilih untuk menyimpannya
+	recordAnswers := req.URL.Query().Get("record") == "true"
+
 	// ambil teks awal dari AI
-	asset, err := ai.GetChatAsset()
+	asset, err := ai.GetChatAsset(language)
 	if err != nil {
 		log.Printf("failed to get initial text: %v", err)
 		sendResponse(w, nil, "failed to get initial text", http.StatusInternalServerError)
@@ -68,6 +377,18 @@ func (h *handler) StartChat(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	// di mode panel, pesan pembuka diberikan oleh persona pertama
+	lead := template.Panel.Lead()
+
+	// ambil audio awal dari cache TTS
+	speechKey, _, err := h.tts.Synthesize(asset.ChatText, lead.Voice)
+	if err != nil {
+		log.Printf("failed to create initial speech: %v", err)
+		sendResponse(w, nil, "failed to create initial speech", http.StatusInternalServerError)
+
+		return
+	}
+
 	// buat kata sandi
 	plainSecret := generateRandom()
 	hashed, err := createHash(plainSecret)
@@ -78,20 +399,41 @@ func (h *handler) StartChat(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	// buat chat baru
+	// buat chat baru, pesan pembuka dihitung sebagai pertanyaan pertama di plan
+	now := time.Now()
+	progress := template.Plan.Start(now)
+	history := []data.Turn{
+		data.NewTurn(ai.ChatMessage{
+			Role:    ai.ROLE_SYSTEM,
+			Content: asset.SystemPrompt,
+		}),
+	}
+	if template.Panel.Enabled() {
+		history = append(history, data.NewTurn(ai.ChatMessage{
+			Role:    ai.ROLE_SYSTEM,
+			Content: interview.PanelInstruction(template.Panel),
+		}))
+	}
+	history = append(history, data.Turn{
+		Role:       ai.ROLE_ASSISTANT,
+		Content:    asset.ChatText,
+		CreatedAt:  now,
+		Stage:      template.Plan.Current(progress).Stage,
+		Speaker:    lead.ID,
+		SpeechText: asset.ChatText,
+	})
+
 	entry := data.ChatEntry{
-		Secret: hashed,
-		History: []ai.ChatMessage{
-			{
-				Role:    ai.ROLE_SYSTEM,
-				Content: asset.SystemPrompt,
-			},
-			{
-				Role:    ai.ROLE_ASSISTANT,
-				Content: asset.ChatText,
-			},
-		},
+		Secret:        hashed,
+		Template:      template.Name,
+		Language:      language,
+		RecordAnswers: recordAnswers,
+		History:       history,
+		Progress:      progress,
+		Questions:     h.questions.Sample(template.Plan),
+		CreatedAt:     now,
 	}
+	h.touchChat(&entry, now)
 
 	newID, err := h.db.InsertChat(entry)
 	if err != nil {
@@ -103,11 +445,15 @@ func (h *handler) StartChat(w http.ResponseWriter, req *http.Request) {
 
 	// kirim respons awal
 	initialChat := model.StartChatResponse{
-		ID:     newID,
-		Secret: plainSecret,
+		ID:       newID,
+		Secret:   plainSecret,
+		Template: template.Name,
+		Language: string(language),
+		Stage:    string(template.Plan.Current(progress).Stage),
 		Chat: model.Chat{
-			Text:  asset.ChatText,
-			Audio: asset.ChatAudio,
+			Text:     asset.ChatText,
+			AudioURL: speechURL(speechKey),
+			Speaker:  speakerResponse(template.Panel, lead.ID),
 		},
 	}
 
@@ -115,31 +461,15 @@ func (h *handler) StartChat(w http.ResponseWriter, req *http.Request) {
 }
 
 func (h *handler) AnswerChat(w http.ResponseWriter, req *http.Request) {
-	// ambil user ID dan kata sandi dari konteks (diatur oleh middleware)
-	userID := req.Context().Value(contextKeyUserID).(string)
-	userSecret := req.Context().Value(contextKeyUserSecret).(string)
-
-	// pastikan user ID dan kata sandi tidak kosong
-	if userID == "" || userSecret == "" {
-		log.Println("user ID or secret is missing")
-		sendResponse(w, nil, "missing required authentication", http.StatusUnauthorized)
-
-		return
-	}
-
-	// ambil chat entry berdasarkan user ID
-	entry, err := h.db.GetChat(userID)
-	if err != nil {
-		log.Printf("failed to get chat: %v", err)
-		sendResponse(w, nil, "failed to get chat", http.StatusInternalServerError)
-
+	// ambil chat entry milik user yang sedang login
+	entry, ok := h.authorizeChat(w, req)
+	if !ok {
 		return
 	}
 
-	// bandingkan kata sandi
-	if err := compareHash(userSecret, entry.Secret); err != nil {
-		log.Println("invalid user secret")
-		sendResponse(w, nil, "invalid user secret", http.StatusUnauthorized)
+	// interview yang sudah selesai tidak menerima jawaban baru
+	if entry.Progress.Finished {
+		sendResponse(w, nil, "interview has finished", http.StatusConflict)
 
 		return
 	}
@@ -160,14 +490,32 @@ func (h *handler) AnswerChat(w http.ResponseWriter, req *http.Request) {
 	}
 	defer file.Close()
 
+	// baca rekaman sebelum dikirim ke AI jika user memilih untuk menyimpannya
+	var recordingData []byte
+	recordedAt := time.Now()
+	if entry.RecordAnswers {
+		recordingData, err = io.ReadAll(file)
+		if err == nil {
+			_, err = file.Seek(0, io.SeekStart)
+		}
+		if err != nil {
+			log.Printf("failed to read recording: %v", err)
+			sendResponse(w, nil, "failed to read file", http.StatusInternalServerError)
+
+			return
+		}
+	}
+
 	// ubah audio menjadi teks
-	transcript, err := h.ai.Transcribe(file, fileHeader.Filename)
+	transcribeStart := time.Now()
+	transcript, err := h.ai.Transcribe(file, fileHeader.Filename, entry.Language)
 	if err != nil {
 		log.Printf("failed to transcribe audio: %v", err)
 		sendResponse(w, nil, "failed to transcribe audio", http.StatusInternalServerError)
 
 		return
 	}
+	transcribeLatency := time.Since(transcribeStart)
 
 	// pastikan teks tidak kosong
 	if transcript.Text == "" {
@@ -177,75 +525,399 @@ func (h *handler) AnswerChat(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	// samarkan data pribadi sebelum dikirim ke AI dan disimpan
+	answerText, redactions := h.redactor.Redact(transcript.Text, entry.ID)
+
+	// analisis cara user berbicara dari waktu kata dan segmen
+	metrics := speech.Analyze(transcript)
+
+	// giliran baru dikumpulkan dulu lalu disimpan sekaligus di akhir
+	var newTurns []data.Turn
+
+	// simpan bahasa hasil deteksi dan beri tahu AI untuk melanjutkan dalam bahasa tersebut
+	var detectedLanguage ai.Language
+	if entry.Language.IsAuto() {
+		if detected, ok := ai.LanguageFromName(transcript.Language); ok {
+			detectedLanguage = detected
+			newTurns = append(newTurns, data.NewTurn(ai.ChatMessage{
+				Role:    ai.ROLE_SYSTEM,
+				Content: ai.LanguageInstruction(detected),
+			}))
+		}
+	}
+
 	// gabungkan teks ke chat history
-	chatHistory := append(entry.History, ai.ChatMessage{
-		Role:    ai.ROLE_USER,
-		Content: transcript.Text,
+	userTurn := data.Turn{
+		Role:       ai.ROLE_USER,
+		Content:    answerText,
+		CreatedAt:  recordedAt,
+		Model:      transcript.Model,
+		Stage:      h.templateFor(entry).Plan.Current(entry.Progress).Stage,
+		QuestionID: lastQuestionID(entry),
+		// transkrip mentah disimpan terpisah dari Content yang sudah disamarkan dan ikut dienkripsi seperti history
+		Transcript: transcript.Text,
+		Speech:     &metrics,
+		Latency: data.Latency{
+			Transcription: transcribeLatency,
+		},
+	}
+
+	// simpan rekaman jawaban di samping pesan user
+	if entry.RecordAnswers {
+		recording, err := h.storeRecording(entry, recordingData, fileHeader.Header.Get("Content-Type"), recordedAt)
+		if err != nil {
+			log.Printf("failed to store recording: %v", err)
+			sendResponse(w, nil, "failed to store recording", http.StatusInternalServerError)
+
+			return
+		}
+
+		userTurn.Recording = &recording
+	}
+
+	entry, userTurn, assistantTurn, ok := h.respond(w, entry, answer{
+		turns:            newTurns,
+		user:             userTurn,
+		detectedLanguage: detectedLanguage,
+		redactions:       redactions,
 	})
+	if !ok {
+		// rekaman dari jawaban yang gagal diproses tidak pernah masuk ke chat
+		if userTurn.Recording != nil {
+			h.discardBlob(userTurn.Recording.BlobID)
+		}
 
-	// kirim history ke AI
-	chatCompletion, err := h.ai.Chat(chatHistory)
+		return
+	}
+
+	var recordingURL string
+	if userTurn.Recording != nil {
+		recordingURL = recordingPath(userTurn.Recording.Turn)
+	}
+
+	// kirim respons
+	response := model.AnswerChatResponse{
+		Language: string(entry.Language),
+		Stage:    string(h.templateFor(entry).Plan.Current(entry.Progress).Stage),
+		Finished: entry.Progress.Finished,
+		Prompt: model.Chat{
+			Text:     redact.Restore(answerText, entry.Redactions),
+			AudioURL: recordingURL,
+		},
+		Answer: model.Chat{
+			Text:     redact.Restore(assistantTurn.Content, entry.Redactions),
+			AudioURL: h.signedAudioURL(entry.ID, assistantTurn.Audio.BlobID),
+			Speaker:  speakerResponse(h.templateFor(entry).Panel, assistantTurn.Speaker),
+		},
+		Problem: codingProblem(entry, assistantTurn),
+	}
+
+	sendResponse(w, response, "success", http.StatusOK)
+}
+
+// answer berisi jawaban user yang siap diproses oleh interviewer
+type answer struct {
+	// turns berisi giliran sebelum jawaban user, misalnya instruksi bahasa hasil deteksi
+	turns            []data.Turn
+	user             data.Turn
+	detectedLanguage ai.Language
+	redactions       redact.Mapping
+}
+
+// respond digunakan untuk mengecek jawaban user, meminta balasan interviewer, membuat audionya,
+// lalu menyimpan semua giliran baru ke chat. Respons error sudah dikirim jika ok bernilai false
+func (h *handler) respond(w http.ResponseWriter, entry data.ChatEntry, ans answer) (updated data.ChatEntry, userTurn, assistantTurn data.Turn, ok bool) {
+	userTurn = ans.user
+	newTurns := ans.turns
+
+	// cek moderasi dan prompt injection sebelum jawaban dikirim ke AI
+	answerText := userTurn.Content
+	check, err := h.guard.Check(answerText)
 	if err != nil {
-		log.Printf("failed to get chat completion: %v", err)
-		sendResponse(w, nil, "failed to get chat completion", http.StatusInternalServerError)
+		log.Printf("failed to check answer: %v", err)
+		sendResponse(w, nil, "failed to check answer", http.StatusInternalServerError)
 
-		return
+		return entry, userTurn, assistantTurn, false
 	}
 
-	// pastikan chat completion tidak kosong
-	if len(chatCompletion.Choices) == 0 {
-		log.Println("cannot complete chat completion: no chat completion")
-		sendResponse(w, nil, "cannot complete chat completion", http.StatusInternalServerError)
+	for _, flag := range check.Flags {
+		userTurn.Flags = append(userTurn.Flags, data.Flag{
+			Kind:   flag.Kind,
+			Detail: flag.Detail,
+			Action: string(check.Action),
+		})
+	}
 
-		return
+	switch check.Action {
+	case guard.ACTION_REFUSE:
+		// jawaban yang ditolak tidak pernah dikirim ke AI, isi aslinya tetap ada di Transcript
+		userTurn.Content = withheldAnswer
+	case guard.ACTION_NEUTRALIZE:
+		newTurns = append(newTurns, data.NewTurn(ai.ChatMessage{
+			Role:    ai.ROLE_SYSTEM,
+			Content: guard.NeutralizeInstruction,
+		}))
+		userTurn.Content = guard.Neutralize(answerText)
+	}
+
+	newTurns = append(newTurns, userTurn)
+
+	// balasan untuk jawaban yang ditolak tidak membutuhkan AI
+	assistantTurn = data.Turn{
+		Role:    ai.ROLE_ASSISTANT,
+		Content: guard.RefusalMessage(entry.Language),
 	}
 
-	speechInput := sanitizeString(chatCompletion.Choices[0].Message.Content)
+	// jawaban yang ditolak tidak menggerakkan plan
+	plan := h.templateFor(entry).Plan
+	progress := entry.Progress
+	assistantTurn.Stage = plan.Current(progress).Stage
+
+	// di mode panel, balasan untuk jawaban yang ditolak diberikan oleh persona terakhir
+	panel := h.templateFor(entry).Panel
+	persona := panel.Next(assistantTurn.Stage, speakers(entry), true)
+
+	if check.Action != guard.ACTION_REFUSE {
+		// tentukan tahap giliran ini dan sisipkan instruksinya tanpa disimpan ke history
+		progress = plan.Next(progress, entry.Questions, time.Now())
+		assistantTurn.Stage = plan.Current(progress).Stage
+
+		messages := data.Messages(append(entry.History, newTurns...))
+		messages = append(messages, ai.ChatMessage{
+			Role:    ai.ROLE_SYSTEM,
+			Content: plan.Instruction(progress),
+		})
+
+		// tentukan apakah jawaban digali lebih dalam atau pindah ke pertanyaan lain
+		decision := h.decide(entry, progress, userTurn)
+
+		// minta AI mengajukan pertanyaan bank soal berikutnya jika ada
+		if question, ok := plan.NextQuestion(progress, entry.Questions, decision); ok {
+			assistantTurn.QuestionID = question.ID
+			messages = append(messages, ai.ChatMessage{
+				Role:    ai.ROLE_SYSTEM,
+				Content: interview.QuestionInstruction(question),
+			})
+
+			// kuota tahap tidak cukup untuk pertanyaan lanjutan
+			if decision == interview.DECISION_PROBE {
+				decision = interview.DECISION_MOVE_ON
+			}
+		}
+
+		if instruction := interview.PolicyInstruction(decision); instruction != "" {
+			assistantTurn.Decision = decision
+			messages = append(messages, ai.ChatMessage{
+				Role:    ai.ROLE_SYSTEM,
+				Content: instruction,
+			})
+		}
+
+		// beri interviewer analisis bicara user untuk feedback di akhir interview
+		if plan.Current(progress).Stage == interview.STAGE_WRAP_UP {
+			metrics := entry.SpeechMetrics()
+			if userTurn.Speech != nil {
+				metrics = append(metrics, *userTurn.Speech)
+			}
+
+			if feedback := speech.Summarize(metrics).Feedback(); feedback != "" {
+				messages = append(messages, ai.ChatMessage{
+					Role:    ai.ROLE_SYSTEM,
+					Content: feedback + "\nInclude brief feedback on how the interviewee spoke, such as pace, filler words, and pauses.",
+				})
+			}
+		}
+
+		// pilih persona yang berbicara, pertanyaan lanjutan diberikan oleh persona yang sama
+		if panel.Enabled() {
+			persona = panel.Next(assistantTurn.Stage, speakers(entry), decision == interview.DECISION_PROBE)
+			messages = append(messages, ai.ChatMessage{
+				Role:    ai.ROLE_SYSTEM,
+				Content: interview.PersonaInstruction(persona),
+			})
+		}
+
+		// kirim history ke AI
+		completionStart := time.Now()
+		chatCompletion, err := h.ai.Chat(messages)
+		if err != nil {
+			log.Printf("failed to get chat completion: %v", err)
+			sendResponse(w, nil, "failed to get chat completion", http.StatusInternalServerError)
+
+			return entry, userTurn, assistantTurn, false
+		}
+
+		// pastikan chat completion tidak kosong
+		if len(chatCompletion.Choices) == 0 {
+			log.Println("cannot complete chat completion: no chat completion")
+			sendResponse(w, nil, "cannot complete chat completion", http.StatusInternalServerError)
+
+			return entry, userTurn, assistantTurn, false
+		}
+
+		usage := chatCompletion.Usage
+		assistantTurn.Content = chatCompletion.Choices[0].Message.Content
+		assistantTurn.Model = chatCompletion.Model
+		assistantTurn.Usage = &usage
+		assistantTurn.Latency.Completion = time.Since(completionStart)
+
+		progress = plan.Asked(progress, time.Now())
+	}
 
-	// buat audio dari teks AI
-	speech, err := h.ai.TextToSpeech(speechInput)
+	assistantTurn.Speaker = persona.ID
+	speechInput := h.redactor.Speakable(sanitizeString(assistantTurn.Content))
+
+	// buat audio dari teks AI dengan suara persona, audio hanya disimpan terenkripsi di blob store.
+	// Balasan penolakan sama untuk semua user sehingga audionya diambil dari cache TTS
+	speechStart := time.Now()
+	var speech []byte
+	if check.Action == guard.ACTION_REFUSE {
+		speechInput = assistantTurn.Content
+		_, speech, err = h.tts.Synthesize(speechInput, persona.Voice)
+	} else {
+		speech, err = h.tts.Speak(speechInput, persona.Voice)
+	}
 	if err != nil {
 		log.Printf("failed to create speech: %v", err)
 		sendResponse(w, nil, "failed to create speech", http.StatusInternalServerError)
 
-		return
+		return entry, userTurn, assistantTurn, false
 	}
+	assistantTurn.Latency.Speech = time.Since(speechStart)
 
-	// ubah audio menjadi base64
-	speechByte, err := io.ReadAll(speech)
+	// simpan audio agar bisa diambil melalui URL
+	speechID, err := h.blobs.PutBlob(data.Blob{
+		ChatID:      entry.ID,
+		ContentType: "audio/mpeg",
+		Data:        speech,
+	})
 	if err != nil {
-		log.Printf("failed to read speech: %v", err)
-		sendResponse(w, nil, "failed to read speech", http.StatusInternalServerError)
+		log.Printf("failed to store speech: %v", err)
+		sendResponse(w, nil, "failed to store speech", http.StatusInternalServerError)
 
-		return
+		return entry, userTurn, assistantTurn, false
 	}
-	speechBase64 := base64.StdEncoding.EncodeToString(speechByte)
 
 	// gabungkan teks AI ke chat history
-	chatHistory = append(chatHistory, ai.ChatMessage{
-		Role:    ai.ROLE_ASSISTANT,
-		Content: chatCompletion.Choices[0].Message.Content,
+	assistantTurn.CreatedAt = time.Now()
+	assistantTurn.SpeechText = speechInput
+	assistantTurn.Audio = &data.AudioRef{
+		BlobID:      speechID,
+		ContentType: "audio/mpeg",
+		Provider:    h.tts.Provider(persona.Voice),
+	}
+	newTurns = append(newTurns, assistantTurn)
+
+	// update chat entry, giliran baru ditambahkan ke history terbaru jika terjadi konflik
+	var userIndex int
+	entry, err = h.updateChat(entry, func(e *data.ChatEntry) {
+		if ans.detectedLanguage != "" && e.Language.IsAuto() {
+			e.Language = ans.detectedLanguage
+		}
+
+		// nomor giliran rekaman mengikuti jumlah jawaban di history terbaru
+		if userTurn.Recording != nil {
+			userTurn.Recording.Turn = e.AnswerCount() + 1
+		}
+
+		// jawaban user selalu tepat sebelum giliran AI terakhir
+		userIndex = len(e.History) + len(newTurns) - 2
+		e.History = append(e.History, newTurns...)
+
+		// progress dihitung dari chat sebelum update. Penilaian, analisis, dan link share yang berjalan
+		// bersamaan tidak mengubah progress, sedangkan dua jawaban yang dikirim bersamaan membuat
+		// progress jawaban terakhir yang tersimpan, seperti jika jawaban pertama tidak pernah dikirim
+		e.Progress = progress
+		if assistantTurn.QuestionID != "" {
+			e.Questions.MarkAsked(assistantTurn.QuestionID, assistantTurn.CreatedAt)
+		}
+
+		// simpan nilai asli data pribadi agar bisa ditampilkan kembali
+		if len(ans.redactions) > 0 && e.Redactions == nil {
+			e.Redactions = make(map[string]string)
+		}
+		for placeholder, value := range ans.redactions {
+			e.Redactions[placeholder] = value
+		}
 	})
+	if err != nil {
+		h.discardBlob(speechID)
+	}
+	if errors.Is(err, data.ErrConflict) {
+		log.Printf("failed to update chat: %v", err)
+		sendResponse(w, nil, "chat was updated by another request, please try again", http.StatusConflict)
 
-	// update chat entry
-	entry.History = chatHistory
-	if err := h.db.UpdateChat(userID, entry); err != nil {
+		return entry, userTurn, assistantTurn, false
+	}
+	if err != nil {
 		log.Printf("failed to update chat: %v", err)
 		sendResponse(w, nil, "failed to update chat", http.StatusInternalServerError)
 
-		return
+		return entry, userTurn, assistantTurn, false
 	}
 
-	// kirim respons
-	response := model.AnswerChatResponse{
-		Prompt: model.Chat{
-			Text: transcript.Text,
-		},
-		Answer: model.Chat{
-			Text:  chatCompletion.Choices[0].Message.Content,
-			Audio: speechBase64,
-		},
+	// nilai jawaban yang sudah disimpan tanpa menunda respons, sehingga request yang gagal tidak pernah dinilai
+	if check.Action != guard.ACTION_REFUSE {
+		h.background(func() { h.scoreAnswer(entry.ID, userIndex) })
+
+		if userTurn.Stage == interview.STAGE_BEHAVIORAL {
+			h.background(func() { h.analyzeSTAR(entry.ID, userIndex) })
+		}
+
+		if userTurn.QuestionID != "" {
+			h.background(func() { h.evaluateCoverage(entry.ID, userTurn.QuestionID, userTurn.Content) })
+		}
 	}
 
-	sendResponse(w, response, "success", http.StatusOK)
+	return entry, userTurn, assistantTurn, true
+}
+
+// maxBackgroundTasks adalah jumlah maksimal penilaian dan analisis jawaban yang berjalan bersamaan
+const maxBackgroundTasks = 8
+
+// background digunakan untuk menjalankan pekerjaan tanpa menunda respons. Pekerjaan menunggu giliran
+// jika sudah ada maxBackgroundTasks yang berjalan agar lonjakan jawaban tidak membanjiri API AI
+func (h *handler) background(task func()) {
+	go func() {
+		h.tasks <- struct{}{}
+		defer func() { <-h.tasks }()
+
+		task()
+	}()
+}
+
+// maxUpdateAttempts adalah jumlah percobaan update chat ketika terjadi konflik
+const maxUpdateAttempts = 3
+
+// updateChat digunakan untuk menyimpan perubahan chat dengan optimistic locking,
+// jika chat sudah diubah request lain, chat dibaca ulang dan perubahan diterapkan kembali.
+// apply dipanggil ulang dengan chat terbaru di setiap percobaan, sehingga nilai yang bergantung
+// pada isi chat harus dihitung dari e di dalam apply. Nilai yang dihitung sebelum updateChat
+// dari chat lama akan menimpa nilai terbaru apa adanya
+func (h *handler) updateChat(entry data.ChatEntry, apply func(*data.ChatEntry)) (data.ChatEntry, error) {
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		updated := entry
+		updated.History = append([]data.Turn(nil), entry.History...)
+		updated.Questions = append(interview.Questions(nil), entry.Questions...)
+		updated.Shares = append([]data.Share(nil), entry.Shares...)
+		apply(&updated)
+		h.touchChat(&updated, time.Now())
+
+		err := h.db.UpdateChat(updated.ID, updated)
+		if err == nil {
+			updated.Version++
+			return updated, nil
+		}
+		if !errors.Is(err, data.ErrConflict) {
+			return entry, err
+		}
+
+		// baca ulang chat terbaru sebelum mencoba lagi
+		entry, err = h.db.GetChat(entry.ID)
+		if err != nil {
+			return entry, err
+		}
+	}
+
+	return entry, data.ErrConflict
 }
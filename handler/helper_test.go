@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/guard"
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+	"github.com/fastcampus-backend-golang/ai-interview/redact"
+	"github.com/fastcampus-backend-golang/ai-interview/share"
+	"github.com/fastcampus-backend-golang/ai-interview/tts"
+)
+
+// fakeAI adalah ai.Client yang tidak memanggil API. Chat membalas dengan teks tetap atau chatErr jika diisi
+// dan mencatat pesan yang dikirim, StructuredChat membalas dengan JSON dari structured berdasarkan nama schema
+// dan menghitung pemanggilannya di calls, dan Transcribe membalas dengan transcript atau teks tetap jika kosong
+type fakeAI struct {
+	mu         sync.Mutex
+	chats      [][]ai.ChatMessage
+	chatErr    error
+	structured map[string]string
+	calls      map[string]int
+	transcript string
+}
+
+func (f *fakeAI) Chat(messages []ai.ChatMessage) (ai.ChatResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.chats = append(f.chats, messages)
+	if f.chatErr != nil {
+		return ai.ChatResponse{}, f.chatErr
+	}
+
+	return fakeResponse("Can you tell me more?"), nil
+}
+
+func (f *fakeAI) StructuredChat(messages []ai.ChatMessage, schema ai.JSONSchema) (ai.ChatResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.calls == nil {
+		f.calls = make(map[string]int)
+	}
+	f.calls[schema.Name]++
+
+	if content, ok := f.structured[schema.Name]; ok {
+		return fakeResponse(content), nil
+	}
+
+	return fakeResponse("{}"), nil
+}
+
+func (f *fakeAI) TextToSpeech(input, voice string) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader("audio:" + voice + ":" + input)), nil
+}
+
+func (f *fakeAI) Transcribe(file io.ReadCloser, name string, language ai.Language) (ai.TranscriptResponse, error) {
+	text := f.transcript
+	if text == "" {
+		text = "I would use a buffered channel."
+	}
+
+	return ai.TranscriptResponse{Text: text, Language: "english"}, nil
+}
+
+func (f *fakeAI) Moderate(input string) (ai.ModerationResponse, error) {
+	return ai.ModerationResponse{}, nil
+}
+
+// callCount digunakan untuk mengambil jumlah pemanggilan StructuredChat dengan schema tertentu
+func (f *fakeAI) callCount(schema string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls[schema]
+}
+
+// lastChat digunakan untuk mengambil pesan yang dikirim di pemanggilan Chat terakhir
+func (f *fakeAI) lastChat() []ai.ChatMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.chats) == 0 {
+		return nil
+	}
+
+	return f.chats[len(f.chats)-1]
+}
+
+// fakeResponse digunakan untuk membuat respons chat completion dengan satu pilihan
+func fakeResponse(content string) ai.ChatResponse {
+	return ai.ChatResponse{
+		Model:   "fake",
+		Choices: []ai.Choice{{Message: ai.ChatMessage{Role: ai.ROLE_ASSISTANT, Content: content}}},
+	}
+}
+
+// newTestHandler digunakan untuk membuat handler dengan penyimpanan di memori, template dari repo,
+// dan fakeAI sebagai client AI
+func newTestHandler(t *testing.T) *handler {
+	t.Helper()
+
+	memory := data.NewMemory()
+	client := &fakeAI{structured: map[string]string{}}
+
+	blobs, err := data.NewFileSystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create blob store: %v", err)
+	}
+
+	questions, err := interview.LoadBank("../questions")
+	if err != nil {
+		t.Fatalf("failed to load question bank: %v", err)
+	}
+
+	templates, err := loadTemplates("../templates", questions, false)
+	if err != nil {
+		t.Fatalf("failed to load templates: %v", err)
+	}
+
+	shares, err := share.NewSigner([]byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("failed to create share signer: %v", err)
+	}
+
+	cache, err := tts.NewCache(16, "")
+	if err != nil {
+		t.Fatalf("failed to create tts cache: %v", err)
+	}
+
+	redactor, err := redact.New(redact.RuleNames())
+	if err != nil {
+		t.Fatalf("failed to create redactor: %v", err)
+	}
+
+	answerGuard, err := guard.NewGuard(guard.NewLocalModerator(), guard.ACTION_NEUTRALIZE)
+	if err != nil {
+		t.Fatalf("failed to create guard: %v", err)
+	}
+
+	return &handler{
+		ai:                 client,
+		tts:                tts.NewSynthesizer(client, "fake", "alloy", cache),
+		redactor:           redactor,
+		guard:              answerGuard,
+		db:                 memory,
+		blobs:              blobs,
+		questions:          questions,
+		accounts:           memory,
+		shareAccesses:      memory,
+		reviews:            memory,
+		admins:             adminTokens{},
+		shares:             shares,
+		audioLinks:         shares.Derive("audio"),
+		chatTTL:            time.Hour,
+		recordingRetention: time.Hour,
+		templates:          templates,
+		templateDir:        "../templates",
+		tasks:              make(chan struct{}, maxBackgroundTasks),
+		sandboxRuns:        make(chan struct{}, maxSandboxRuns),
+	}
+}
+
+// insertTestChat digunakan untuk menyimpan chat dengan template default dan mengembalikan kata sandinya
+func insertTestChat(t *testing.T, h *handler) (data.ChatEntry, string) {
+	t.Helper()
+
+	secret := generateRandom()
+	hashed, err := createHash(secret)
+	if err != nil {
+		t.Fatalf("failed to create hash: %v", err)
+	}
+
+	template, _ := h.template("")
+	now := time.Now()
+	entry := data.ChatEntry{
+		Secret:   hashed,
+		Template: template.Name,
+		Language: ai.LANGUAGE_EN,
+		History: []data.Turn{
+			data.NewTurn(ai.ChatMessage{Role: ai.ROLE_SYSTEM, Content: "system prompt"}),
+			{Role: ai.ROLE_ASSISTANT, Content: "Tell me about yourself.", CreatedAt: now, Stage: interview.STAGE_INTRO},
+		},
+		Progress:  template.Plan.Start(now),
+		CreatedAt: now,
+	}
+	h.touchChat(&entry, now)
+
+	id, err := h.db.InsertChat(entry)
+	if err != nil {
+		t.Fatalf("failed to insert chat: %v", err)
+	}
+
+	entry, err = h.db.GetChat(id)
+	if err != nil {
+		t.Fatalf("failed to get chat: %v", err)
+	}
+
+	return entry, secret
+}
+
+// setSession digunakan untuk menambahkan header Basic auth sesi interview ke request
+func setSession(req *http.Request, id, secret string) {
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(id+":"+secret)))
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+	"github.com/fastcampus-backend-golang/ai-interview/redact"
+	"github.com/go-chi/chi"
+)
+
+// maxBackground adalah jumlah maksimal jawaban latar belakang user yang dipakai untuk contoh jawaban
+const maxBackground = 5
+
+func (h *handler) GetIdealAnswer(w http.ResponseWriter, req *http.Request) {
+	// ambil chat entry milik user yang sedang login
+	entry, ok := h.authorizeChat(w, req)
+	if !ok {
+		return
+	}
+
+	// contoh jawaban hanya diberikan setelah interview selesai agar tidak membantu menjawab pertanyaan berikutnya
+	if !entry.Progress.Finished {
+		sendResponse(w, nil, "interview has not finished", http.StatusConflict)
+
+		return
+	}
+
+	turn, err := strconv.Atoi(chi.URLParam(req, "turn"))
+	if err != nil {
+		sendResponse(w, nil, "invalid turn", http.StatusBadRequest)
+
+		return
+	}
+
+	index, ok := entry.AnswerIndex(turn)
+	if !ok {
+		sendResponse(w, nil, "answer not found", http.StatusNotFound)
+
+		return
+	}
+
+	// hanya jawaban lisan yang diteruskan ke AI yang memiliki contoh jawaban
+	t := entry.History[index]
+	if t.Transcript == "" || t.Content == withheldAnswer {
+		sendResponse(w, nil, "ideal answer is only available for spoken answers", http.StatusConflict)
+
+		return
+	}
+
+	// contoh jawaban dibuat sekali lalu disimpan di chat
+	if t.Ideal == nil {
+		ideal, err := interview.GenerateIdealAnswer(h.ai, idealRequest(entry, index))
+		if err != nil {
+			log.Printf("failed to generate ideal answer: %v", err)
+			sendResponse(w, nil, "failed to generate ideal answer", http.StatusInternalServerError)
+
+			return
+		}
+
+		entry, err = h.updateChat(entry, func(e *data.ChatEntry) {
+			if e.History[index].Ideal == nil {
+				e.History[index].Ideal = &ideal
+			}
+		})
+		if errors.Is(err, data.ErrConflict) {
+			log.Printf("failed to update chat: %v", err)
+			sendResponse(w, nil, "chat was updated by another request, please try again", http.StatusConflict)
+
+			return
+		}
+		if err != nil {
+			log.Printf("failed to update chat: %v", err)
+			sendResponse(w, nil, "failed to update chat", http.StatusInternalServerError)
+
+			return
+		}
+		t = entry.History[index]
+	}
+
+	sendResponse(w, idealResponse(turn, questionBefore(entry, index), t, entry.Redactions), "success", http.StatusOK)
+}
+
+// idealRequest digunakan untuk mengumpulkan pertanyaan, poin jawaban, dan latar belakang user untuk contoh jawaban
+func idealRequest(entry data.ChatEntry, index int) interview.IdealRequest {
+	t := entry.History[index]
+	req := interview.IdealRequest{
+		Question: questionBefore(entry, index),
+		Answer:   t.Content,
+	}
+
+	if question, ok := entry.Questions.Get(t.QuestionID); ok {
+		req.KeyPoints = question.KeyPoints
+		req.Covered = question.Covered
+		req.Evaluated = question.Evaluated
+	}
+
+	// latar belakang user diambil dari jawaban di tahap perkenalan dan pengalaman
+	for i, previous := range entry.History {
+		if len(req.Background) == maxBackground {
+			break
+		}
+		if i == index || previous.Role != ai.ROLE_USER || previous.Content == withheldAnswer {
+			continue
+		}
+		if previous.Stage == interview.STAGE_INTRO || previous.Stage == interview.STAGE_EXPERIENCE {
+			req.Background = append(req.Background, previous.Content)
+		}
+	}
+
+	return req
+}
+
+// idealResponse digunakan untuk mengubah contoh jawaban menjadi respons API
+func idealResponse(turn int, question string, t data.Turn, redactions redact.Mapping) model.IdealAnswer {
+	ideal := *t.Ideal
+	response := model.IdealAnswer{
+		Turn:        turn,
+		QuestionID:  t.QuestionID,
+		Question:    redact.Restore(question, redactions),
+		Answer:      t.Transcript,
+		Ideal:       redact.Restore(ideal.Text(), redactions),
+		Segments:    []model.IdealSegment{},
+		KeyPoints:   []model.KeyPoint{},
+		Missing:     []string{},
+		GeneratedAt: ideal.GeneratedAt,
+	}
+
+	for _, segment := range ideal.Segments {
+		response.Segments = append(response.Segments, model.IdealSegment{
+			Text:     redact.Restore(segment.Text, redactions),
+			KeyPoint: segment.KeyPoint,
+			Missing:  segment.Missing,
+		})
+	}
+	for _, keyPoint := range ideal.KeyPoints {
+		response.KeyPoints = append(response.KeyPoints, model.KeyPoint{
+			Text:    keyPoint.Text,
+			Covered: keyPoint.Covered,
+		})
+	}
+	response.Missing = append(response.Missing, ideal.Missing()...)
+
+	return response
+}
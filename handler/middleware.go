@@ -3,8 +3,13 @@ package handler
 import (
 	"context"
 	"encoding/base64"
+	"errors"
+	"log"
 	"net/http"
 	"strings"
+
+	"github.com/fastcampus-backend-golang/ai-interview/access"
+	"github.com/fastcampus-backend-golang/ai-interview/data"
 )
 
 type contextKey string
@@ -12,6 +17,7 @@ type contextKey string
 const (
 	contextKeyUserID     contextKey = "user-id"
 	contextKeyUserSecret contextKey = "user-secret"
+	contextKeyPrincipal  contextKey = "principal"
 )
 
 func authMiddleware(next http.Handler) http.Handler {
@@ -48,6 +54,67 @@ func authMiddleware(next http.Handler) http.Handler {
 		r = r.WithContext(context.WithValue(r.Context(), contextKeyUserID, userID))
 		r = r.WithContext(context.WithValue(r.Context(), contextKeyUserSecret, userSecret))
 
+		// kata sandi dicek oleh handler saat mengambil chat, pemilik sesi selalu berperan kandidat
+		// dan izinnya dicek oleh requirePermission di setiap rute /chat
+		r = r.WithContext(context.WithValue(r.Context(), contextKeyPrincipal, access.Principal{
+			ID:   userID,
+			Role: access.ROLE_CANDIDATE,
+		}))
+
 		next.ServeHTTP(w, r)
 	})
 }
+
+// tokenMiddleware digunakan untuk memastikan request reviewer, admin, atau service membawa token
+// yang terdaftar dengan format "Authorization: Bearer <token>", pemilik token disimpan di konteks
+func (h *handler) tokenMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		principal, err := h.lookupToken(token)
+		if errors.Is(err, data.ErrAccountNotFound) {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			log.Printf("failed to get account: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		r = r.WithContext(context.WithValue(r.Context(), contextKeyPrincipal, principal))
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requirePermission digunakan untuk menolak request dari pengguna yang perannya tidak boleh melakukan aksi,
+// dipasang setelah middleware yang menyimpan pengguna di konteks
+func requirePermission(permission access.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := principalFrom(r)
+			if !ok {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			if !principal.Can(permission) {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// principalFrom digunakan untuk mengambil pengguna yang sedang mengakses API dari konteks
+func principalFrom(r *http.Request) (access.Principal, bool) {
+	principal, ok := r.Context().Value(contextKeyPrincipal).(access.Principal)
+	return principal, ok
+}
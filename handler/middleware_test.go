@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/access"
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+)
+
+// testTokens adalah token akun untuk setiap peran staf yang dipakai di test rute
+var testTokens = map[access.Role]string{
+	access.ROLE_REVIEWER: "reviewer-token-0123456789",
+	access.ROLE_SERVICE:  "service-token-0123456789",
+	access.ROLE_ADMIN:    "admin-token-0123456789",
+}
+
+// insertTestAccount digunakan untuk menyimpan akun staf dengan token dari testTokens
+func insertTestAccount(t *testing.T, h *handler, role access.Role) string {
+	t.Helper()
+
+	token := testTokens[role]
+	_, err := h.accounts.InsertAccount(data.Account{Name: string(role), Role: role, TokenHash: hashToken(token), CreatedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("failed to insert account: %v", err)
+	}
+
+	return token
+}
+
+// setTestAuth digunakan untuk menambahkan header login sesuai peran, peran kosong berarti tanpa login
+func setTestAuth(t *testing.T, h *handler, req *http.Request, role access.Role) {
+	t.Helper()
+
+	switch role {
+	case "":
+	case access.ROLE_CANDIDATE:
+		entry, secret := insertTestChat(t, h)
+		setSession(req, entry.ID, secret)
+	default:
+		req.Header.Set("Authorization", "Bearer "+insertTestAccount(t, h, role))
+	}
+}
+
+func TestRoutePermissions(t *testing.T) {
+	roles := []access.Role{"", access.ROLE_CANDIDATE, access.ROLE_REVIEWER, access.ROLE_SERVICE, access.ROLE_ADMIN}
+	staff := []access.Role{access.ROLE_REVIEWER, access.ROLE_SERVICE, access.ROLE_ADMIN}
+	candidate := []access.Role{access.ROLE_CANDIDATE}
+
+	tests := []struct {
+		method string
+		path   string
+		// allowed adalah peran yang lolos, peran staf lain ditolak dengan 403 di rute /admin
+		allowed []access.Role
+	}{
+		{method: http.MethodPost, path: "/chat/answer", allowed: candidate},
+		{method: http.MethodPost, path: "/chat/code", allowed: candidate},
+		{method: http.MethodPost, path: "/chat/design", allowed: candidate},
+		{method: http.MethodGet, path: "/chat/design", allowed: candidate},
+		{method: http.MethodGet, path: "/chat/scores", allowed: candidate},
+		{method: http.MethodGet, path: "/chat/answers/1/ideal", allowed: candidate},
+		{method: http.MethodGet, path: "/chat/export", allowed: candidate},
+		{method: http.MethodPost, path: "/chat/shares", allowed: candidate},
+		{method: http.MethodGet, path: "/chat/shares", allowed: candidate},
+		{method: http.MethodDelete, path: "/chat/shares/missing", allowed: candidate},
+		{method: http.MethodDelete, path: "/chat", allowed: candidate},
+		{method: http.MethodGet, path: "/chat/audio/missing", allowed: candidate},
+		{method: http.MethodGet, path: "/chat/recordings", allowed: candidate},
+		{method: http.MethodGet, path: "/chat/recordings/1", allowed: candidate},
+
+		{method: http.MethodGet, path: "/admin/chats", allowed: staff},
+		{method: http.MethodGet, path: "/admin/chats/missing", allowed: staff},
+		{method: http.MethodGet, path: "/admin/chats/missing/export", allowed: staff},
+		{method: http.MethodGet, path: "/admin/chats/missing/scores", allowed: staff},
+		{method: http.MethodDelete, path: "/admin/chats/missing", allowed: []access.Role{access.ROLE_ADMIN}},
+		{method: http.MethodGet, path: "/admin/chats/missing/review", allowed: staff},
+		{method: http.MethodPut, path: "/admin/chats/missing/review", allowed: []access.Role{access.ROLE_REVIEWER, access.ROLE_ADMIN}},
+		{method: http.MethodPost, path: "/admin/chats/missing/review/comments", allowed: []access.Role{access.ROLE_REVIEWER, access.ROLE_ADMIN}},
+		{method: http.MethodDelete, path: "/admin/chats/missing/review/comments/missing", allowed: []access.Role{access.ROLE_REVIEWER, access.ROLE_ADMIN}},
+		{method: http.MethodPut, path: "/admin/chats/missing/review/scores/communication", allowed: []access.Role{access.ROLE_REVIEWER, access.ROLE_ADMIN}},
+		{method: http.MethodDelete, path: "/admin/chats/missing/review/scores/communication", allowed: []access.Role{access.ROLE_REVIEWER, access.ROLE_ADMIN}},
+		{method: http.MethodGet, path: "/admin/stats", allowed: []access.Role{access.ROLE_SERVICE, access.ROLE_ADMIN}},
+		{method: http.MethodGet, path: "/admin/templates", allowed: []access.Role{access.ROLE_ADMIN}},
+		{method: http.MethodPost, path: "/admin/templates/reload", allowed: []access.Role{access.ROLE_ADMIN}},
+		{method: http.MethodGet, path: "/admin/accounts", allowed: []access.Role{access.ROLE_ADMIN}},
+		{method: http.MethodPost, path: "/admin/accounts", allowed: []access.Role{access.ROLE_ADMIN}},
+		{method: http.MethodDelete, path: "/admin/accounts/missing", allowed: []access.Role{access.ROLE_ADMIN}},
+	}
+
+	for _, tt := range tests {
+		for _, role := range roles {
+			name := string(role)
+			if name == "" {
+				name = "anonymous"
+			}
+
+			t.Run(tt.method+" "+tt.path+" as "+name, func(t *testing.T) {
+				h := newTestHandler(t)
+				req := httptest.NewRequest(tt.method, tt.path, nil)
+				setTestAuth(t, h, req, role)
+
+				rec := httptest.NewRecorder()
+				h.routes().ServeHTTP(rec, req)
+
+				allowed := false
+				for _, r := range tt.allowed {
+					allowed = allowed || r == role
+				}
+
+				switch {
+				case allowed:
+					if rec.Code == http.StatusUnauthorized || rec.Code == http.StatusForbidden {
+						t.Errorf("status = %d, want the request to pass", rec.Code)
+					}
+				case role == "" || role == access.ROLE_CANDIDATE || tt.allowed[0] == access.ROLE_CANDIDATE:
+					// rute /admin hanya menerima token akun dan rute /chat hanya menerima sesi interview
+					if rec.Code != http.StatusUnauthorized {
+						t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+					}
+				default:
+					if rec.Code != http.StatusForbidden {
+						t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestCandidateSessionIsCheckedOnChatRoutes(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret func(secret string) string
+		want   int
+	}{
+		{name: "valid secret", secret: func(secret string) string { return secret }, want: http.StatusOK},
+		{name: "wrong secret", secret: func(string) string { return "wrong" }, want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler(t)
+			entry, secret := insertTestChat(t, h)
+
+			req := httptest.NewRequest(http.MethodGet, "/chat/shares", nil)
+			setSession(req, entry.ID, tt.secret(secret))
+
+			rec := httptest.NewRecorder()
+			h.routes().ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		principal  *access.Principal
+		permission access.Permission
+		want       int
+	}{
+		{name: "candidate with permission", principal: &access.Principal{Role: access.ROLE_CANDIDATE}, permission: access.PERMISSION_TAKE_INTERVIEW, want: http.StatusOK},
+		{name: "candidate without permission", principal: &access.Principal{Role: access.ROLE_CANDIDATE}, permission: access.PERMISSION_LIST_CHATS, want: http.StatusForbidden},
+		{name: "staff without permission", principal: &access.Principal{Role: access.ROLE_REVIEWER}, permission: access.PERMISSION_TAKE_INTERVIEW, want: http.StatusForbidden},
+		{name: "no principal", permission: access.PERMISSION_TAKE_INTERVIEW, want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.principal != nil {
+				req = req.WithContext(context.WithValue(req.Context(), contextKeyPrincipal, *tt.principal))
+			}
+
+			rec := httptest.NewRecorder()
+			requirePermission(tt.permission)(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+)
+
+// speakers digunakan untuk mendapatkan persona yang berbicara di setiap giliran AI, dari yang paling lama
+func speakers(entry data.ChatEntry) []string {
+	var ids []string
+	for _, t := range entry.History {
+		if t.Role == ai.ROLE_ASSISTANT && t.Speaker != "" {
+			ids = append(ids, t.Speaker)
+		}
+	}
+
+	return ids
+}
+
+// speakerResponse digunakan untuk mengubah persona menjadi respons API, nil jika bukan mode panel
+func speakerResponse(panel interview.Panel, id string) *model.Speaker {
+	persona, ok := panel.Get(id)
+	if !ok {
+		return nil
+	}
+
+	return &model.Speaker{
+		ID:   persona.ID,
+		Name: persona.Name,
+		Role: persona.Role,
+	}
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/guard"
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+)
+
+// templateFor digunakan untuk mengambil template chat,
+// template default dipakai jika template chat sudah tidak tersedia
+func (h *handler) templateFor(entry data.ChatEntry) interview.Template {
+	if template, ok := h.template(entry.Template); ok {
+		return template
+	}
+
+	template, _ := h.template(interview.DefaultTemplate)
+	return template
+}
+
+// decide digunakan untuk mengambil keputusan policy template setelah jawaban user,
+// kosong berarti policy tidak dipakai karena tahap berganti atau tidak diatur di policy
+func (h *handler) decide(entry data.ChatEntry, progress interview.Progress, userTurn data.Turn) interview.Decision {
+	template := h.templateFor(entry)
+	stage := template.Plan.Current(progress)
+
+	last := progress.StageIndex >= len(template.Plan.Stages)-1
+	if last || stage.Stage != userTurn.Stage || !template.Policy.Applies(stage.Stage) {
+		return ""
+	}
+
+	return template.Policy.Decide(policySignals(entry, userTurn))
+}
+
+// policySignals digunakan untuk mengumpulkan data jawaban terakhir untuk policy
+func policySignals(entry data.ChatEntry, userTurn data.Turn) interview.Signals {
+	signals := interview.Signals{
+		// jawaban yang dinetralkan dibungkus tag yang tidak ikut dihitung
+		AnswerWords: len(strings.Fields(guard.Unwrap(userTurn.Content))),
+	}
+
+	// hitung pertanyaan lanjutan berturut-turut dari giliran AI terakhir
+	for i := len(entry.History) - 1; i >= 0; i-- {
+		t := entry.History[i]
+		if t.Role != ai.ROLE_ASSISTANT {
+			continue
+		}
+		if t.Stage != userTurn.Stage || t.Decision != interview.DECISION_PROBE {
+			break
+		}
+
+		signals.FollowUps++
+	}
+
+	return signals
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/guard"
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+)
+
+func TestPolicySignals(t *testing.T) {
+	tests := []struct {
+		name     string
+		history  []data.Turn
+		userTurn data.Turn
+		want     interview.Signals
+	}{
+		{
+			name: "answer words are counted",
+			history: []data.Turn{
+				{Role: ai.ROLE_ASSISTANT, Stage: interview.STAGE_TECHNICAL},
+			},
+			userTurn: data.Turn{Content: "goroutines and channels"},
+			want:     interview.Signals{AnswerWords: 3},
+		},
+		{
+			name: "consecutive follow-ups are counted across answers",
+			history: []data.Turn{
+				{Role: ai.ROLE_ASSISTANT, Stage: interview.STAGE_TECHNICAL, Decision: interview.DECISION_MOVE_ON},
+				{Role: ai.ROLE_USER, Stage: interview.STAGE_TECHNICAL},
+				{Role: ai.ROLE_ASSISTANT, Stage: interview.STAGE_TECHNICAL, Decision: interview.DECISION_PROBE},
+				{Role: ai.ROLE_USER, Stage: interview.STAGE_TECHNICAL},
+				{Role: ai.ROLE_SYSTEM, Content: "instruction"},
+				{Role: ai.ROLE_ASSISTANT, Stage: interview.STAGE_TECHNICAL, Decision: interview.DECISION_PROBE},
+			},
+			userTurn: data.Turn{Content: "goroutines and channels"},
+			want:     interview.Signals{AnswerWords: 3, FollowUps: 2},
+		},
+		{
+			name: "follow-ups from the previous stage are ignored",
+			history: []data.Turn{
+				{Role: ai.ROLE_ASSISTANT, Stage: interview.STAGE_EXPERIENCE, Decision: interview.DECISION_PROBE},
+				{Role: ai.ROLE_USER, Stage: interview.STAGE_EXPERIENCE},
+				{Role: ai.ROLE_ASSISTANT, Stage: interview.STAGE_TECHNICAL},
+			},
+			userTurn: data.Turn{Content: "goroutines and channels"},
+			want:     interview.Signals{AnswerWords: 3},
+		},
+		{
+			name: "neutralize tags are not counted as words",
+			history: []data.Turn{
+				{Role: ai.ROLE_ASSISTANT, Stage: interview.STAGE_TECHNICAL},
+			},
+			userTurn: data.Turn{Content: guard.Neutralize("goroutines and channels")},
+			want:     interview.Signals{AnswerWords: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := data.ChatEntry{History: tt.history}
+			userTurn := tt.userTurn
+			userTurn.Role, userTurn.Stage = ai.ROLE_USER, interview.STAGE_TECHNICAL
+
+			if got := policySignals(entry, userTurn); got != tt.want {
+				t.Errorf("policySignals() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// technicalTestChat digunakan untuk membuat chat test yang sudah berada di tahap technical yang memakai policy
+func technicalTestChat(t *testing.T, h *handler) data.ChatEntry {
+	t.Helper()
+
+	entry, _ := insertTestChat(t, h)
+
+	template, _ := h.template("")
+	stageIndex := slices.IndexFunc(template.Plan.Stages, func(stage interview.StagePlan) bool {
+		return stage.Stage == interview.STAGE_TECHNICAL
+	})
+	entry, err := h.updateChat(entry, func(e *data.ChatEntry) {
+		e.Progress = interview.Progress{StageIndex: stageIndex, StageStartedAt: time.Now(), QuestionsAsked: 1}
+		e.History[len(e.History)-1].Stage = interview.STAGE_TECHNICAL
+	})
+	if err != nil {
+		t.Fatalf("failed to move chat to the technical stage: %v", err)
+	}
+
+	return entry
+}
+
+func TestRespondScoresSavedAnswer(t *testing.T) {
+	h := newTestHandler(t)
+	client := h.ai.(*fakeAI)
+	client.structured["rubric_scores"] = `{"scores":[{"competency":"technical-knowledge","level":4,"evidence":"explains internals"}]}`
+
+	entry := technicalTestChat(t, h)
+
+	w := httptest.NewRecorder()
+	entry, _, assistantTurn, ok := h.respond(w, entry, answer{user: data.Turn{
+		Role:      ai.ROLE_USER,
+		Content:   strings.Repeat("I would use a buffered channel with a worker pool. ", 5),
+		CreatedAt: time.Now(),
+		Stage:     interview.STAGE_TECHNICAL,
+	}})
+	if !ok {
+		t.Fatalf("respond() failed: %d %s", w.Code, w.Body.String())
+	}
+
+	if assistantTurn.Decision != interview.DECISION_MOVE_ON {
+		t.Errorf("decision = %s, want %s", assistantTurn.Decision, interview.DECISION_MOVE_ON)
+	}
+
+	instruction := interview.PolicyInstruction(interview.DECISION_MOVE_ON)
+	if !slices.ContainsFunc(client.lastChat(), func(message ai.ChatMessage) bool { return message.Content == instruction }) {
+		t.Errorf("%s policy instruction was not sent to the AI", interview.DECISION_MOVE_ON)
+	}
+
+	// nilai jawaban disimpan di background setelah giliran tersimpan
+	userIndex := len(entry.History) - 2
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		got, err := h.db.GetChat(entry.ID)
+		if err != nil {
+			t.Fatalf("failed to get chat: %v", err)
+		}
+		if len(got.History[userIndex].Scores) > 0 && got.Scorecard != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("answer scores were not saved")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRespondFailureDoesNotScore(t *testing.T) {
+	h := newTestHandler(t)
+	client := h.ai.(*fakeAI)
+	client.chatErr = errors.New("chat failed")
+
+	entry := technicalTestChat(t, h)
+	before := len(entry.History)
+
+	w := httptest.NewRecorder()
+	_, _, _, ok := h.respond(w, entry, answer{user: data.Turn{
+		Role:      ai.ROLE_USER,
+		Content:   "I would use a buffered channel",
+		CreatedAt: time.Now(),
+		Stage:     interview.STAGE_TECHNICAL,
+	}})
+	if ok {
+		t.Fatal("respond() succeeded, want failure")
+	}
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+
+	got, err := h.db.GetChat(entry.ID)
+	if err != nil {
+		t.Fatalf("failed to get chat: %v", err)
+	}
+	if len(got.History) != before {
+		t.Errorf("history length = %d, want %d", len(got.History), before)
+	}
+	if n := client.callCount("rubric_scores"); n != 0 {
+		t.Errorf("rubric scoring calls = %d, want 0", n)
+	}
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"log"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+)
+
+// lastQuestionID digunakan untuk mendapatkan pertanyaan bank soal yang diajukan di giliran AI terakhir
+func lastQuestionID(entry data.ChatEntry) string {
+	for i := len(entry.History) - 1; i >= 0; i-- {
+		if entry.History[i].Role == ai.ROLE_ASSISTANT {
+			return entry.History[i].QuestionID
+		}
+	}
+
+	return ""
+}
+
+// evaluateCoverage digunakan untuk menilai poin jawaban pertanyaan bank soal dan menyimpannya ke chat
+func (h *handler) evaluateCoverage(chatID, questionID, answer string) {
+	entry, err := h.db.GetChat(chatID)
+	if err != nil {
+		log.Printf("failed to get chat for coverage: %v", err)
+		return
+	}
+
+	question, ok := entry.Questions.Get(questionID)
+	if !ok {
+		return
+	}
+
+	covered, err := interview.EvaluateCoverage(h.ai, question, answer)
+	if err != nil {
+		log.Printf("failed to evaluate coverage of question %s: %v", questionID, err)
+		return
+	}
+
+	_, err = h.updateChat(entry, func(e *data.ChatEntry) {
+		e.Questions.SetCoverage(questionID, covered)
+	})
+	if err != nil {
+		log.Printf("failed to save coverage of question %s: %v", questionID, err)
+	}
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+	"github.com/go-chi/chi"
+)
+
+func (h *handler) ListRecordings(w http.ResponseWriter, req *http.Request) {
+	// ambil chat entry milik user yang sedang login
+	entry, ok := h.authorizeChat(w, req)
+	if !ok {
+		return
+	}
+
+	recordings := []model.Recording{}
+	for _, t := range entry.History {
+		if t.Recording == nil {
+			continue
+		}
+
+		recording := t.Recording
+		recordings = append(recordings, model.Recording{
+			Turn:       recording.Turn,
+			Transcript: t.Transcript,
+			AudioURL:   recordingPath(recording.Turn),
+			RecordedAt: recording.RecordedAt,
+			ExpiresAt:  recording.ExpiresAt,
+		})
+	}
+
+	sendResponse(w, recordings, "success", http.StatusOK)
+}
+
+func (h *handler) GetRecording(w http.ResponseWriter, req *http.Request) {
+	// ambil chat entry milik user yang sedang login
+	entry, ok := h.authorizeChat(w, req)
+	if !ok {
+		return
+	}
+
+	turn, err := strconv.Atoi(chi.URLParam(req, "turn"))
+	if err != nil {
+		sendResponse(w, nil, "invalid turn", http.StatusBadRequest)
+
+		return
+	}
+
+	// pastikan rekaman ada dan belum kedaluwarsa
+	recording, ok := entry.GetRecording(turn)
+	if !ok || time.Now().After(recording.ExpiresAt) {
+		sendResponse(w, nil, "recording not found", http.StatusNotFound)
+
+		return
+	}
+
+	blob, err := h.blobs.GetBlob(recording.BlobID)
+	if errors.Is(err, data.ErrBlobNotFound) {
+		sendResponse(w, nil, "recording not found", http.StatusNotFound)
+
+		return
+	}
+	if err != nil {
+		log.Printf("failed to get recording: %v", err)
+		sendResponse(w, nil, "failed to get recording", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", blob.ContentType)
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	http.ServeContent(w, req, blob.ID, blob.CreatedAt, bytes.NewReader(blob.Data))
+}
+
+// storeRecording digunakan untuk menyimpan rekaman jawaban ke blob store
+func (h *handler) storeRecording(entry data.ChatEntry, audio []byte, contentType string, recordedAt time.Time) (data.Recording, error) {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	blobID, err := h.blobs.PutBlob(data.Blob{
+		ChatID:      entry.ID,
+		ContentType: contentType,
+		Data:        audio,
+		CreatedAt:   recordedAt,
+	})
+	if err != nil {
+		return data.Recording{}, err
+	}
+
+	return data.Recording{
+		Turn:        entry.AnswerCount() + 1,
+		BlobID:      blobID,
+		ContentType: contentType,
+		RecordedAt:  recordedAt,
+		ExpiresAt:   recordedAt.Add(h.recordingRetention),
+	}, nil
+}
+
+// recordingPath digunakan untuk membuat URL rekaman berdasarkan nomor giliran
+func recordingPath(turn int) string {
+	return fmt.Sprintf("/chat/recordings/%d", turn)
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+)
+
+// sweepInterval adalah jeda antar pengecekan chat dan rekaman yang kedaluwarsa
+const sweepInterval = time.Hour
+
+func (h *handler) DeleteChat(w http.ResponseWriter, req *http.Request) {
+	// ambil chat entry milik user yang sedang login
+	entry, ok := h.authorizeChat(w, req)
+	if !ok {
+		return
+	}
+
+	h.deleteChat(w, entry)
+}
+
+// deleteChat digunakan untuk menghapus chat beserta semua audio, akses link share, dan review-nya lalu mengirim responsnya
+func (h *handler) deleteChat(w http.ResponseWriter, entry data.ChatEntry) {
+	// hapus semua audio terlebih dahulu agar tidak ada data yang tertinggal
+	if err := h.blobs.DeleteChatBlobs(entry.ID); err != nil {
+		log.Printf("failed to delete chat audio: %v", err)
+		sendResponse(w, nil, "failed to delete chat", http.StatusInternalServerError)
+
+		return
+	}
+
+	if err := h.shareAccesses.DeleteShareAccesses(entry.ID); err != nil {
+		log.Printf("failed to delete chat share accesses: %v", err)
+		sendResponse(w, nil, "failed to delete chat", http.StatusInternalServerError)
+
+		return
+	}
+
+	if err := h.reviews.DeleteReview(entry.ID); err != nil {
+		log.Printf("failed to delete chat review: %v", err)
+		sendResponse(w, nil, "failed to delete chat", http.StatusInternalServerError)
+
+		return
+	}
+
+	if err := h.db.DeleteChat(entry.ID); err != nil {
+		log.Printf("failed to delete chat: %v", err)
+		sendResponse(w, nil, "failed to delete chat", http.StatusInternalServerError)
+
+		return
+	}
+
+	sendResponse(w, nil, "chat deleted", http.StatusOK)
+}
+
+// discardBlob digunakan untuk menghapus blob yang tidak jadi dirujuk chat karena chat gagal disimpan,
+// blob seperti ini tidak akan pernah dihapus oleh retensi
+func (h *handler) discardBlob(id string) {
+	err := h.blobs.DeleteBlob(id)
+	if err != nil && !errors.Is(err, data.ErrBlobNotFound) {
+		log.Printf("failed to delete unreferenced blob %s: %v", id, err)
+	}
+}
+
+// touchChat digunakan untuk memperbarui waktu ubah dan waktu kedaluwarsa chat
+func (h *handler) touchChat(entry *data.ChatEntry, now time.Time) {
+	entry.UpdatedAt = now
+
+	ttl := h.chatTTL
+	if template, ok := h.template(entry.Template); ok && template.Retention > 0 {
+		ttl = time.Duration(template.Retention)
+	}
+
+	entry.ExpiresAt = time.Time{}
+	if ttl > 0 {
+		entry.ExpiresAt = now.Add(ttl)
+	}
+}
+
+// sweep digunakan untuk menghapus chat dan rekaman kedaluwarsa secara berkala
+func (h *handler) sweep(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; true; <-ticker.C {
+		h.sweepRecordings()
+		h.sweepChats()
+	}
+}
+
+// sweepRecordings digunakan untuk menghapus rekaman yang melewati masa simpan
+func (h *handler) sweepRecordings() {
+	expired, err := h.db.ExpireRecordings(time.Now())
+	if err != nil {
+		log.Printf("failed to expire recordings: %v", err)
+	}
+
+	for _, recording := range expired {
+		err := h.blobs.DeleteBlob(recording.BlobID)
+		if err != nil && !errors.Is(err, data.ErrBlobNotFound) {
+			log.Printf("failed to delete recording %s: %v", recording.BlobID, err)
+		}
+	}
+}
+
+// sweepChats digunakan untuk menghapus chat yang melewati masa simpan beserta audio, akses link share, dan review-nya
+func (h *handler) sweepChats() {
+	expired, err := h.db.ExpireChats(time.Now())
+	if err != nil {
+		log.Printf("failed to expire chats: %v", err)
+	}
+
+	for _, id := range expired {
+		if err := h.blobs.DeleteChatBlobs(id); err != nil {
+			log.Printf("failed to delete audio of chat %s: %v", id, err)
+		}
+
+		if err := h.shareAccesses.DeleteShareAccesses(id); err != nil {
+			log.Printf("failed to delete share accesses of chat %s: %v", id, err)
+		}
+
+		if err := h.reviews.DeleteReview(id); err != nil {
+			log.Printf("failed to delete review of chat %s: %v", id, err)
+		}
+	}
+}
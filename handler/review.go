@@ -0,0 +1,351 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fastcampus-backend-golang/ai-interview/access"
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxReviewTextLength adalah panjang maksimal komentar dan alasan override dalam karakter
+	maxReviewTextLength = 4000
+
+	// maxReviewRequestSize adalah ukuran maksimal body permintaan mengubah review
+	maxReviewRequestSize = 32 << 10
+)
+
+func (h *handler) GetReview(w http.ResponseWriter, req *http.Request) {
+	review, err := h.reviews.GetReview(chi.URLParam(req, "id"))
+	if errors.Is(err, data.ErrReviewNotFound) {
+		// chat yang belum direview mendapatkan review kosong selama chatnya masih ada
+		if _, ok := h.getChat(w, req); !ok {
+			return
+		}
+
+		sendResponse(w, reviewResponse(nil, true), "success", http.StatusOK)
+
+		return
+	}
+	if err != nil {
+		log.Printf("failed to get review: %v", err)
+		sendResponse(w, nil, "failed to get review", http.StatusInternalServerError)
+
+		return
+	}
+
+	sendResponse(w, reviewResponse(&review, true), "success", http.StatusOK)
+}
+
+func (h *handler) AddReviewComment(w http.ResponseWriter, req *http.Request) {
+	entry, ok := h.getChat(w, req)
+	if !ok {
+		return
+	}
+
+	var body model.ReviewCommentRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxReviewRequestSize)).Decode(&body); err != nil {
+		sendResponse(w, nil, "invalid request body", http.StatusBadRequest)
+
+		return
+	}
+
+	if body.Turn < 1 || body.Turn > entry.AnswerCount() {
+		sendResponse(w, nil, fmt.Sprintf("turn must be between 1 and %d", entry.AnswerCount()), http.StatusBadRequest)
+
+		return
+	}
+
+	text, ok := reviewText(body.Text)
+	if !ok {
+		sendResponse(w, nil, fmt.Sprintf("text is required and must be at most %d characters", maxReviewTextLength), http.StatusBadRequest)
+
+		return
+	}
+
+	comment := data.ReviewComment{
+		ID:        uuid.New().String(),
+		Turn:      body.Turn,
+		Author:    reviewer(req),
+		Text:      text,
+		CreatedAt: time.Now(),
+	}
+
+	h.saveReview(w, entry.ID, func(r *data.Review) bool {
+		r.AddComment(comment)
+		return true
+	})
+}
+
+func (h *handler) DeleteReviewComment(w http.ResponseWriter, req *http.Request) {
+	entry, ok := h.getChat(w, req)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(req, "comment")
+	actor := reviewer(req)
+	h.saveReview(w, entry.ID, func(r *data.Review) bool {
+		if !r.DeleteComment(id, actor, time.Now()) {
+			sendResponse(w, nil, "comment not found", http.StatusNotFound)
+
+			return false
+		}
+
+		return true
+	})
+}
+
+func (h *handler) OverrideScore(w http.ResponseWriter, req *http.Request) {
+	entry, ok := h.getChat(w, req)
+	if !ok {
+		return
+	}
+
+	competency, ok := h.templateFor(entry).Rubric.Get(chi.URLParam(req, "competency"))
+	if !ok {
+		sendResponse(w, nil, "competency not found", http.StatusNotFound)
+
+		return
+	}
+
+	var body model.ScoreOverrideRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxReviewRequestSize)).Decode(&body); err != nil {
+		sendResponse(w, nil, "invalid request body", http.StatusBadRequest)
+
+		return
+	}
+
+	if body.Level < 1 || body.Level > len(competency.Levels) {
+		sendResponse(w, nil, fmt.Sprintf("level must be between 1 and %d", len(competency.Levels)), http.StatusBadRequest)
+
+		return
+	}
+
+	// override selalu membutuhkan alasan agar keputusan reviewer bisa diaudit
+	justification, ok := reviewText(body.Justification)
+	if !ok {
+		sendResponse(w, nil, fmt.Sprintf("justification is required and must be at most %d characters", maxReviewTextLength), http.StatusBadRequest)
+
+		return
+	}
+
+	override := data.ScoreOverride{
+		Competency:    competency.ID,
+		Level:         body.Level,
+		Justification: justification,
+		Author:        reviewer(req),
+		At:            time.Now(),
+	}
+
+	h.saveReview(w, entry.ID, func(r *data.Review) bool {
+		r.Override(override)
+		return true
+	})
+}
+
+func (h *handler) RemoveScoreOverride(w http.ResponseWriter, req *http.Request) {
+	entry, ok := h.getChat(w, req)
+	if !ok {
+		return
+	}
+
+	competency := chi.URLParam(req, "competency")
+	actor := reviewer(req)
+	h.saveReview(w, entry.ID, func(r *data.Review) bool {
+		if !r.RemoveOverride(competency, actor, time.Now()) {
+			sendResponse(w, nil, "override not found", http.StatusNotFound)
+
+			return false
+		}
+
+		return true
+	})
+}
+
+func (h *handler) UpdateReview(w http.ResponseWriter, req *http.Request) {
+	entry, ok := h.getChat(w, req)
+	if !ok {
+		return
+	}
+
+	var body model.UpdateReviewRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxReviewRequestSize)).Decode(&body); err != nil {
+		sendResponse(w, nil, "invalid request body", http.StatusBadRequest)
+
+		return
+	}
+
+	if body.Reviewed == nil && body.Published == nil {
+		sendResponse(w, nil, "reviewed or published is required", http.StatusBadRequest)
+
+		return
+	}
+
+	actor := reviewer(req)
+	h.saveReview(w, entry.ID, func(r *data.Review) bool {
+		// hanya review yang sudah selesai yang bisa dilihat kandidat
+		reviewed := r.Reviewed()
+		if body.Reviewed != nil {
+			reviewed = *body.Reviewed
+		}
+		if body.Published != nil && *body.Published && !reviewed {
+			sendResponse(w, nil, "review must be marked as reviewed before publishing", http.StatusConflict)
+
+			return false
+		}
+
+		now := time.Now()
+		if body.Reviewed != nil {
+			r.SetReviewed(*body.Reviewed, actor, now)
+		}
+		if body.Published != nil {
+			r.SetPublished(*body.Published, actor, now)
+		}
+
+		return true
+	})
+}
+
+// saveReview digunakan untuk menyimpan perubahan review chat lalu mengirim review terbaru.
+// Review disimpan di luar dokumen chat dengan versinya sendiri sehingga tidak bersaing dengan jawaban kandidat,
+// jika review sudah diubah reviewer lain, review dibaca ulang dan apply dipanggil kembali.
+// apply mengembalikan false setelah mengirim respons jika perubahan tidak bisa diterapkan
+func (h *handler) saveReview(w http.ResponseWriter, chatID string, apply func(*data.Review) bool) {
+	var review data.Review
+	var err error
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		review, err = h.reviews.GetReview(chatID)
+		if errors.Is(err, data.ErrReviewNotFound) {
+			review, err = data.Review{ChatID: chatID}, nil
+		}
+		if err != nil {
+			break
+		}
+
+		if !apply(&review) {
+			return
+		}
+
+		err = h.reviews.SaveReview(review)
+		if !errors.Is(err, data.ErrConflict) {
+			break
+		}
+	}
+	if errors.Is(err, data.ErrConflict) {
+		log.Printf("failed to save review: %v", err)
+		sendResponse(w, nil, "review was updated by another request, please try again", http.StatusConflict)
+
+		return
+	}
+	if err != nil {
+		log.Printf("failed to save review: %v", err)
+		sendResponse(w, nil, "failed to save review", http.StatusInternalServerError)
+
+		return
+	}
+
+	sendResponse(w, reviewResponse(&review, true), "success", http.StatusOK)
+}
+
+// reviewFor digunakan untuk mengambil review chat yang boleh dilihat pengguna,
+// kandidat dan penerima link share hanya bisa melihat review yang sudah dipublikasikan
+func (h *handler) reviewFor(w http.ResponseWriter, req *http.Request, chatID string) (*data.Review, bool) {
+	review, err := h.reviews.GetReview(chatID)
+	if errors.Is(err, data.ErrReviewNotFound) {
+		return nil, true
+	}
+	if err != nil {
+		log.Printf("failed to get review: %v", err)
+		sendResponse(w, nil, "failed to get review", http.StatusInternalServerError)
+
+		return nil, false
+	}
+
+	if principal, ok := principalFrom(req); ok && principal.Can(access.PERMISSION_READ_REVIEW) {
+		return &review, true
+	}
+
+	if review.Published() {
+		return &review, true
+	}
+
+	return nil, true
+}
+
+// reviewer digunakan untuk mendapatkan pengguna yang mengubah review dari konteks
+func reviewer(req *http.Request) data.Reviewer {
+	principal, _ := principalFrom(req)
+	return data.Reviewer{ID: principal.ID, Name: principal.Name}
+}
+
+// reviewText digunakan untuk merapikan dan memvalidasi komentar atau alasan override
+func reviewText(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	return text, text != "" && utf8.RuneCountInString(text) <= maxReviewTextLength
+}
+
+// reviewResponse digunakan untuk mengubah review menjadi respons API,
+// jejak audit hanya disertakan untuk reviewer
+func reviewResponse(review *data.Review, withEvents bool) model.Review {
+	response := model.Review{
+		Comments: []model.ReviewComment{},
+	}
+	if review == nil {
+		if withEvents {
+			response.Events = []model.ReviewEvent{}
+		}
+
+		return response
+	}
+
+	response.Reviewed = review.Reviewed()
+	response.ReviewedBy = review.ReviewedBy.Name
+	response.ReviewedAt = review.ReviewedAt
+	response.Published = review.Published()
+	response.PublishedAt = review.PublishedAt
+
+	for _, comment := range review.Comments {
+		response.Comments = append(response.Comments, reviewCommentResponse(comment))
+	}
+
+	if withEvents {
+		response.Events = []model.ReviewEvent{}
+		for _, event := range review.Events {
+			response.Events = append(response.Events, model.ReviewEvent{
+				Action:        string(event.Action),
+				Actor:         event.Actor.Name,
+				At:            event.At,
+				Turn:          event.Turn,
+				CommentID:     event.CommentID,
+				Competency:    event.Competency,
+				Level:         event.Level,
+				PreviousLevel: event.PreviousLevel,
+				Text:          event.Text,
+			})
+		}
+	}
+
+	return response
+}
+
+// reviewCommentResponse digunakan untuk mengubah komentar reviewer menjadi respons API
+func reviewCommentResponse(comment data.ReviewComment) model.ReviewComment {
+	return model.ReviewComment{
+		ID:        comment.ID,
+		Turn:      comment.Turn,
+		Author:    comment.Author.Name,
+		Text:      comment.Text,
+		CreatedAt: comment.CreatedAt,
+	}
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/access"
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+)
+
+// serveAsReviewer digunakan untuk mengirim request ke rute review sebagai reviewer
+func serveAsReviewer(t *testing.T, h *handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testTokens[access.ROLE_REVIEWER])
+
+	rec := httptest.NewRecorder()
+	h.routes().ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestReviewFollowsChatLifetime(t *testing.T) {
+	tests := []struct {
+		name string
+		// after dijalankan oleh kandidat setelah reviewer menambahkan komentar
+		after func(t *testing.T, h *handler, entry data.ChatEntry, secret string)
+		// wantStatus adalah status GET review setelah after, review ikut terhapus bersama chat
+		wantStatus int
+	}{
+		{
+			name: "candidate update with the previous chat version",
+			after: func(t *testing.T, h *handler, entry data.ChatEntry, secret string) {
+				if _, err := h.updateChat(entry, func(e *data.ChatEntry) { e.Language = entry.Language }); err != nil {
+					t.Fatalf("updateChat() error = %v", err)
+				}
+
+				stored, err := h.db.GetChat(entry.ID)
+				if err != nil {
+					t.Fatalf("failed to get chat: %v", err)
+				}
+				if stored.Version != entry.Version+1 {
+					t.Errorf("chat version = %d, want %d", stored.Version, entry.Version+1)
+				}
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "candidate deletes the chat",
+			after: func(t *testing.T, h *handler, entry data.ChatEntry, secret string) {
+				req := httptest.NewRequest(http.MethodDelete, "/chat", nil)
+				setSession(req, entry.ID, secret)
+
+				rec := httptest.NewRecorder()
+				h.routes().ServeHTTP(rec, req)
+				if rec.Code != http.StatusOK {
+					t.Fatalf("DELETE /chat status = %d, want %d", rec.Code, http.StatusOK)
+				}
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "chat expires",
+			after: func(t *testing.T, h *handler, entry data.ChatEntry, secret string) {
+				// updateChat memperpanjang masa simpan sehingga waktu kedaluwarsa diubah langsung
+				entry.ExpiresAt = time.Now().Add(-time.Minute)
+				if err := h.db.UpdateChat(entry.ID, entry); err != nil {
+					t.Fatalf("UpdateChat() error = %v", err)
+				}
+
+				h.sweepChats()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler(t)
+			entry, secret := insertTestChat(t, h)
+			insertTestAccount(t, h, access.ROLE_REVIEWER)
+
+			// chat uji berisi satu pertanyaan, jawabannya ditambahkan agar komentar bisa dipasang
+			entry, err := h.updateChat(entry, func(e *data.ChatEntry) {
+				e.History = append(e.History, data.Turn{Role: ai.ROLE_USER, Content: "I use goroutines."})
+			})
+			if err != nil {
+				t.Fatalf("failed to answer: %v", err)
+			}
+
+			rec := serveAsReviewer(t, h, http.MethodPost, "/admin/chats/"+entry.ID+"/review/comments", `{"turn": 1, "text": "clear answer"}`)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("add comment status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			// komentar reviewer tidak mengubah versi chat
+			stored, err := h.db.GetChat(entry.ID)
+			if err != nil {
+				t.Fatalf("failed to get chat: %v", err)
+			}
+			if stored.Version != entry.Version {
+				t.Fatalf("chat version = %d after review, want %d", stored.Version, entry.Version)
+			}
+
+			tt.after(t, h, entry, secret)
+
+			rec = serveAsReviewer(t, h, http.MethodGet, "/admin/chats/"+entry.ID+"/review", "")
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("get review status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				if _, err := h.reviews.GetReview(entry.ID); !errors.Is(err, data.ErrReviewNotFound) {
+					t.Errorf("GetReview() error = %v, want %v", err, data.ErrReviewNotFound)
+				}
+
+				return
+			}
+
+			var response struct {
+				Data model.Review `json:"data"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode review: %v", err)
+			}
+			if len(response.Data.Comments) != 1 || len(response.Data.Events) != 1 {
+				t.Errorf("review has %d comments and %d events, want 1 and 1", len(response.Data.Comments), len(response.Data.Events))
+			}
+		})
+	}
+}
+
+func TestGetReviewOfMissingChat(t *testing.T) {
+	h := newTestHandler(t)
+	insertTestAccount(t, h, access.ROLE_REVIEWER)
+
+	rec := serveAsReviewer(t, h, http.MethodGet, "/admin/chats/missing/review", "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+	"github.com/fastcampus-backend-golang/ai-interview/redact"
+	"github.com/fastcampus-backend-golang/ai-interview/speech"
+)
+
+func (h *handler) GetScores(w http.ResponseWriter, req *http.Request) {
+	// ambil chat entry milik user yang sedang login
+	entry, ok := h.authorizeChat(w, req)
+	if !ok {
+		return
+	}
+
+	review, ok := h.reviewFor(w, req, entry.ID)
+	if !ok {
+		return
+	}
+
+	sendResponse(w, h.scorecardResponse(entry, review), "success", http.StatusOK)
+}
+
+// scorecardResponse digunakan untuk membuat laporan nilai, analisis STAR, dan analisis bicara chat,
+// nilai keseluruhan dihitung ulang dengan override dari review jika review tidak kosong
+func (h *handler) scorecardResponse(entry data.ChatEntry, review *data.Review) model.Scorecard {
+	template := h.templateFor(entry)
+
+	// chat yang belum dinilai tetap mendapatkan scorecard kosong dari rubric template
+	scorecard := interview.Aggregate(template.Rubric, nil)
+	if entry.Scorecard != nil {
+		scorecard = *entry.Scorecard
+	}
+
+	automated := scorecard.Overall
+	if levels := review.OverrideLevels(); len(levels) > 0 {
+		scorecard = scorecard.Override(template.Rubric, levels)
+	}
+
+	response := model.Scorecard{
+		Template:      template.Name,
+		RubricVersion: scorecard.RubricVersion,
+		Overall:       scorecard.Overall,
+		ScoredAnswers: scorecard.ScoredTurns,
+		Competencies:  []model.CompetencyScore{},
+		Answers:       []model.AnswerScore{},
+		Behavioral:    []model.BehavioralAnswer{},
+	}
+
+	if scorecard.Overall != automated {
+		response.AutomatedOverall = &automated
+	}
+
+	for _, competency := range scorecard.Competencies {
+		score := model.CompetencyScore{
+			ID:       competency.ID,
+			Name:     competency.Name,
+			Average:  competency.Average,
+			MaxLevel: competency.MaxLevel,
+			Count:    competency.Count,
+		}
+
+		if override, ok := review.GetOverride(competency.ID); ok {
+			score.Override = &model.ScoreOverride{
+				Level:         override.Level,
+				Justification: override.Justification,
+				Author:        override.Author.Name,
+				At:            override.At,
+			}
+		}
+
+		response.Competencies = append(response.Competencies, score)
+	}
+
+	answerNumber := 0
+	for _, t := range entry.History {
+		if t.Role != ai.ROLE_USER {
+			continue
+		}
+		answerNumber++
+
+		if t.STAR != nil {
+			response.Behavioral = append(response.Behavioral, starResponse(answerNumber, t, entry.Redactions))
+		}
+
+		if len(t.Scores) == 0 {
+			continue
+		}
+
+		answer := model.AnswerScore{
+			Turn:       answerNumber,
+			Stage:      string(t.Stage),
+			QuestionID: t.QuestionID,
+		}
+		for _, score := range t.Scores {
+			answer.Scores = append(answer.Scores, model.Score{
+				Competency: score.Competency,
+				Level:      score.Level,
+				Evidence:   redact.Restore(score.Evidence, entry.Redactions),
+			})
+		}
+		response.Answers = append(response.Answers, answer)
+	}
+
+	if latest, submittedAt, ok := entry.LatestDesign(); ok {
+		design := designResponse(latest, submittedAt)
+		response.Design = &design
+	}
+
+	if metrics := entry.SpeechMetrics(); len(metrics) > 0 {
+		summary := speech.Summarize(metrics)
+		response.Speech = &model.SpeechSummary{
+			Answers:              summary.Answers,
+			Words:                summary.Words,
+			SpeakingSeconds:      summary.SpeakingTime.Seconds(),
+			WordsPerMinute:       summary.WordsPerMinute,
+			FillerCount:          summary.FillerCount,
+			FillersPerMinute:     summary.FillersPerMinute,
+			Fillers:              summary.Fillers,
+			LongPauses:           summary.LongPauses,
+			LongestPauseSeconds:  summary.LongestPause.Seconds(),
+			AverageAnswerSeconds: summary.AverageAnswer.Seconds(),
+		}
+	}
+
+	if review != nil {
+		reviewed := reviewResponse(review, false)
+		response.Review = &reviewed
+	}
+
+	return response
+}
+
+// scoreAnswer digunakan untuk menilai jawaban user di history yang sudah disimpan berdasarkan rubric
+// dan memperbarui scorecard chat
+func (h *handler) scoreAnswer(chatID string, index int) {
+	entry, err := h.db.GetChat(chatID)
+	if err != nil {
+		log.Printf("failed to get chat for scoring: %v", err)
+		return
+	}
+
+	if index < 0 || index >= len(entry.History) || entry.History[index].Role != ai.ROLE_USER {
+		return
+	}
+	answer := entry.History[index]
+	question := questionBefore(entry, index)
+
+	scores, err := interview.ScoreAnswer(h.ai, h.templateFor(entry).Rubric, answer.Stage, question, answer.Content)
+	if err != nil {
+		log.Printf("failed to score answer %d of chat %s: %v", index, chatID, err)
+		return
+	}
+	if len(scores) == 0 {
+		return
+	}
+
+	_, err = h.updateChat(entry, func(e *data.ChatEntry) {
+		e.History[index].Scores = scores
+		h.updateScorecard(e)
+	})
+	if err != nil {
+		log.Printf("failed to save scores of answer %d of chat %s: %v", index, chatID, err)
+	}
+}
+
+// updateScorecard digunakan untuk menghitung ulang scorecard chat dari nilai setiap giliran
+func (h *handler) updateScorecard(e *data.ChatEntry) {
+	turns := make([][]interview.Score, 0, len(e.History))
+	for _, t := range e.History {
+		turns = append(turns, t.Scores)
+	}
+
+	scorecard := interview.Aggregate(h.templateFor(*e).Rubric, turns)
+	e.Scorecard = &scorecard
+}
+
+// questionBefore digunakan untuk mendapatkan pertanyaan yang dijawab di giliran tertentu,
+// yaitu giliran AI terakhir sebelum jawaban
+func questionBefore(entry data.ChatEntry, index int) string {
+	for i := index - 1; i >= 0; i-- {
+		if entry.History[i].Role == ai.ROLE_ASSISTANT {
+			return entry.History[i].Content
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+	"github.com/fastcampus-backend-golang/ai-interview/share"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultShareTTL dan maxShareTTL adalah masa berlaku link share default dan maksimal
+	defaultShareTTL = 7 * 24 * time.Hour
+	maxShareTTL     = 30 * 24 * time.Hour
+
+	// maxShareRequestSize adalah ukuran maksimal body permintaan membuat link share
+	maxShareRequestSize = 4 << 10
+)
+
+func (h *handler) CreateShare(w http.ResponseWriter, req *http.Request) {
+	// ambil chat entry milik user yang sedang login
+	entry, ok := h.authorizeChat(w, req)
+	if !ok {
+		return
+	}
+
+	// hanya interview yang sudah selesai yang bisa dibagikan
+	if !entry.Progress.Finished {
+		sendResponse(w, nil, "interview has not finished", http.StatusConflict)
+
+		return
+	}
+
+	// body boleh kosong untuk memakai masa berlaku default
+	var body model.CreateShareRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxShareRequestSize)).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		sendResponse(w, nil, "invalid request body", http.StatusBadRequest)
+
+		return
+	}
+
+	ttl := defaultShareTTL
+	if body.ExpiresIn != "" {
+		parsed, err := time.ParseDuration(body.ExpiresIn)
+		if err != nil || parsed <= 0 || parsed > maxShareTTL {
+			sendResponse(w, nil, fmt.Sprintf("expires_in must be a positive duration of at most %s", maxShareTTL), http.StatusBadRequest)
+
+			return
+		}
+		ttl = parsed
+	}
+
+	// token ditandatangani sampai detik sehingga waktu link juga disimpan dalam detik
+	now := time.Now().Truncate(time.Second)
+	link := data.Share{
+		ID:        uuid.New().String(),
+		Label:     body.Label,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	entry, err := h.updateChat(entry, func(e *data.ChatEntry) {
+		e.Shares = append(e.Shares, link)
+	})
+	if errors.Is(err, data.ErrConflict) {
+		log.Printf("failed to update chat: %v", err)
+		sendResponse(w, nil, "chat was updated by another request, please try again", http.StatusConflict)
+
+		return
+	}
+	if err != nil {
+		log.Printf("failed to save share link: %v", err)
+		sendResponse(w, nil, "failed to create share link", http.StatusInternalServerError)
+
+		return
+	}
+
+	sendResponse(w, h.shareResponse(entry, link, nil), "success", http.StatusOK)
+}
+
+func (h *handler) ListShares(w http.ResponseWriter, req *http.Request) {
+	// ambil chat entry milik user yang sedang login
+	entry, ok := h.authorizeChat(w, req)
+	if !ok {
+		return
+	}
+
+	accesses, err := h.shareAccesses.ListShareAccesses(entry.ID)
+	if err != nil {
+		log.Printf("failed to list share accesses: %v", err)
+		sendResponse(w, nil, "failed to list share links", http.StatusInternalServerError)
+
+		return
+	}
+
+	now := time.Now()
+	shares := []model.Share{}
+	for _, link := range entry.Shares {
+		if link.Active(now) {
+			shares = append(shares, h.shareResponse(entry, link, accesses))
+		}
+	}
+
+	sendResponse(w, shares, "success", http.StatusOK)
+}
+
+func (h *handler) RevokeShare(w http.ResponseWriter, req *http.Request) {
+	// ambil chat entry milik user yang sedang login
+	entry, ok := h.authorizeChat(w, req)
+	if !ok {
+		return
+	}
+
+	index, ok := entry.GetShare(chi.URLParam(req, "id"))
+	if !ok || !entry.Shares[index].RevokedAt.IsZero() {
+		sendResponse(w, nil, "share link not found", http.StatusNotFound)
+
+		return
+	}
+
+	_, err := h.updateChat(entry, func(e *data.ChatEntry) {
+		e.Shares[index].RevokedAt = time.Now()
+	})
+	if errors.Is(err, data.ErrConflict) {
+		log.Printf("failed to update chat: %v", err)
+		sendResponse(w, nil, "chat was updated by another request, please try again", http.StatusConflict)
+
+		return
+	}
+	if err != nil {
+		log.Printf("failed to revoke share link: %v", err)
+		sendResponse(w, nil, "failed to revoke share link", http.StatusInternalServerError)
+
+		return
+	}
+
+	sendResponse(w, nil, "success", http.StatusOK)
+}
+
+func (h *handler) GetShared(w http.ResponseWriter, req *http.Request) {
+	entry, token, ok := h.authorizeShare(w, req, "transcript")
+	if !ok {
+		return
+	}
+
+	review, ok := h.reviewFor(w, req, entry.ID)
+	if !ok {
+		return
+	}
+
+	// penerima link tidak melihat isi jawaban yang ditolak guard
+	sendResponse(w, h.transcript(entry, time.Now(), sharedAudioURL(token), review, false), "success", http.StatusOK)
+}
+
+func (h *handler) ExportShared(w http.ResponseWriter, req *http.Request) {
+	entry, token, ok := h.authorizeShare(w, req, "export")
+	if !ok {
+		return
+	}
+
+	h.sendExport(w, req, entry, sharedAudioURL(token), false)
+}
+
+func (h *handler) GetSharedAudio(w http.ResponseWriter, req *http.Request) {
+	entry, _, ok := h.authorizeShare(w, req, "audio")
+	if !ok {
+		return
+	}
+
+	h.sendAudio(w, req, entry.ID, chi.URLParam(req, "id"))
+}
+
+// authorizeShare digunakan untuk mengambil chat dari token link share dan mencatat aksesnya,
+// link yang dicabut atau kedaluwarsa dianggap tidak ada. Respons error sudah dikirim jika hasilnya false
+func (h *handler) authorizeShare(w http.ResponseWriter, req *http.Request, resource string) (data.ChatEntry, string, bool) {
+	token := chi.URLParam(req, "token")
+	now := time.Now()
+
+	claims, err := h.shares.Verify(token, now)
+	if err != nil {
+		sendResponse(w, nil, "share link not found", http.StatusNotFound)
+
+		return data.ChatEntry{}, "", false
+	}
+
+	entry, err := h.db.GetChat(claims.ChatID)
+	if errors.Is(err, data.ErrChatNotFound) {
+		sendResponse(w, nil, "share link not found", http.StatusNotFound)
+
+		return data.ChatEntry{}, "", false
+	}
+	if err != nil {
+		log.Printf("failed to get shared chat: %v", err)
+		sendResponse(w, nil, "failed to get shared chat", http.StatusInternalServerError)
+
+		return data.ChatEntry{}, "", false
+	}
+
+	index, ok := entry.GetShare(claims.ShareID)
+	if !ok || !entry.Shares[index].Active(now) {
+		sendResponse(w, nil, "share link not found", http.StatusNotFound)
+
+		return data.ChatEntry{}, "", false
+	}
+
+	// akses dicatat di luar dokumen chat, gagal mencatat tidak menggagalkan akses ke transcript
+	err = h.shareAccesses.LogShareAccess(entry.ID, data.ShareAccess{
+		ShareID:   claims.ShareID,
+		Resource:  resource,
+		IP:        remoteIP(req),
+		UserAgent: req.UserAgent(),
+		At:        now,
+	})
+	if err != nil {
+		log.Printf("failed to log share access to chat %s: %v", entry.ID, err)
+	}
+
+	return entry, token, true
+}
+
+// shareResponse digunakan untuk mengubah link share menjadi respons API beserta token dan akses terakhirnya,
+// accesses berisi akses ke semua link chat dan hanya akses ke link ini yang dipakai
+func (h *handler) shareResponse(entry data.ChatEntry, link data.Share, accesses []data.ShareAccess) model.Share {
+	token := h.shares.Sign(share.Claims{
+		ChatID:    entry.ID,
+		ShareID:   link.ID,
+		ExpiresAt: link.ExpiresAt,
+	})
+
+	response := model.Share{
+		ID:        link.ID,
+		Label:     link.Label,
+		Token:     token,
+		URL:       sharedPath(token),
+		CreatedAt: link.CreatedAt,
+		ExpiresAt: link.ExpiresAt,
+		Accesses:  []model.ShareAccess{},
+	}
+
+	for _, access := range accesses {
+		if access.ShareID != link.ID {
+			continue
+		}
+
+		response.Accesses = append(response.Accesses, model.ShareAccess{
+			Resource:  access.Resource,
+			IP:        access.IP,
+			UserAgent: access.UserAgent,
+			At:        access.At,
+		})
+	}
+
+	return response
+}
+
+// sharedPath digunakan untuk membuat URL transcript dari token link share
+func sharedPath(token string) string {
+	return path.Join("/shared", token)
+}
+
+// sharedAudioURL digunakan untuk membuat URL audio yang bisa diambil melalui link share
+func sharedAudioURL(token string) func(string) string {
+	return func(id string) string {
+		return path.Join("/shared", token, "audio", id)
+	}
+}
+
+// remoteIP digunakan untuk mendapatkan alamat IP pembuka link share
+func remoteIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+
+	return host
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/share"
+	"github.com/go-chi/chi"
+)
+
+// failingShareAccesses adalah ShareAccessStore yang selalu gagal mencatat akses
+type failingShareAccesses struct {
+	data.ShareAccessStore
+}
+
+func (failingShareAccesses) LogShareAccess(string, data.ShareAccess) error {
+	return errors.New("share access store is unavailable")
+}
+
+func TestSharedAccessIsLoggedOutsideChat(t *testing.T) {
+	tests := []struct {
+		name      string
+		failing   bool
+		wantCount int
+	}{
+		{name: "access is logged without updating the chat", wantCount: 1},
+		{name: "read succeeds when logging fails", failing: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler(t)
+			if tt.failing {
+				h.shareAccesses = failingShareAccesses{h.shareAccesses}
+			}
+
+			entry, _ := insertTestChat(t, h)
+
+			now := time.Now().Truncate(time.Second)
+			link := data.Share{ID: "share-1", CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+			entry, err := h.updateChat(entry, func(e *data.ChatEntry) {
+				e.Shares = append(e.Shares, link)
+			})
+			if err != nil {
+				t.Fatalf("failed to add share link: %v", err)
+			}
+
+			token := h.shares.Sign(share.Claims{ChatID: entry.ID, ShareID: link.ID, ExpiresAt: link.ExpiresAt})
+
+			r := chi.NewRouter()
+			r.Get("/shared/{token}", h.GetShared)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, sharedPath(token), nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("GET /shared/{token} status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+			}
+
+			stored, err := h.db.GetChat(entry.ID)
+			if err != nil {
+				t.Fatalf("failed to get chat: %v", err)
+			}
+			if stored.Version != entry.Version {
+				t.Errorf("chat version = %d, want %d: logging an access must not update the chat", stored.Version, entry.Version)
+			}
+
+			accesses, err := h.shareAccesses.ListShareAccesses(entry.ID)
+			if err != nil && !tt.failing {
+				t.Fatalf("failed to list share accesses: %v", err)
+			}
+			if len(accesses) != tt.wantCount {
+				t.Errorf("logged %d accesses, want %d", len(accesses), tt.wantCount)
+			}
+		})
+	}
+}
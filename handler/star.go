@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"log"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+	"github.com/fastcampus-backend-golang/ai-interview/data"
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+	"github.com/fastcampus-backend-golang/ai-interview/redact"
+)
+
+// analyzeSTAR digunakan untuk menganalisis struktur STAR jawaban behavioral di history dan menyimpannya ke giliran tersebut
+func (h *handler) analyzeSTAR(chatID string, index int) {
+	entry, err := h.db.GetChat(chatID)
+	if err != nil {
+		log.Printf("failed to get chat for STAR analysis: %v", err)
+		return
+	}
+
+	if index < 0 || index >= len(entry.History) || entry.History[index].Role != ai.ROLE_USER {
+		return
+	}
+
+	analysis, err := interview.AnalyzeSTAR(h.ai, questionBefore(entry, index), entry.History[index].Content)
+	if err != nil {
+		log.Printf("failed to analyze STAR of answer %d of chat %s: %v", index, chatID, err)
+		return
+	}
+
+	_, err = h.updateChat(entry, func(e *data.ChatEntry) {
+		e.History[index].STAR = &analysis
+	})
+	if err != nil {
+		log.Printf("failed to save STAR analysis of answer %d of chat %s: %v", index, chatID, err)
+	}
+}
+
+// starResponse digunakan untuk mengubah analisis STAR di giliran jawaban menjadi respons API
+func starResponse(answerNumber int, t data.Turn, redactions redact.Mapping) model.BehavioralAnswer {
+	response := model.BehavioralAnswer{
+		Turn:        answerNumber,
+		QuestionID:  t.QuestionID,
+		Situation:   redact.Restore(t.STAR.Situation, redactions),
+		Task:        redact.Restore(t.STAR.Task, redactions),
+		Action:      redact.Restore(t.STAR.Action, redactions),
+		Result:      redact.Restore(t.STAR.Result, redactions),
+		Complete:    t.STAR.Complete(),
+		Missing:     append([]string{}, t.STAR.Missing...),
+		Suggestions: []string{},
+	}
+
+	for _, suggestion := range t.STAR.Suggestions {
+		response.Suggestions = append(response.Suggestions, redact.Restore(suggestion, redactions))
+	}
+
+	return response
+}
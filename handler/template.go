@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+	"github.com/fastcampus-backend-golang/ai-interview/model"
+)
+
+// loadTemplates digunakan untuk membaca semua template interview dan memastikan
+// pertanyaan wajib di setiap template tersedia di bank soal
+func loadTemplates(dir string, questions interview.Bank, coding bool) (interview.Templates, error) {
+	templates, err := interview.LoadTemplates(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range templates.Names() {
+		// tahap coding dilewati jika sandbox tidak tersedia karena jawaban kode tidak bisa dijalankan
+		if !coding {
+			template := templates[name]
+			template.Plan = template.Plan.Without(interview.STAGE_CODING)
+
+			// template yang hanya berisi tahap coding tidak bisa dipakai, kecuali template default
+			// yang menjadi cadangan chat dengan template yang tidak ditemukan
+			if err := template.Plan.Validate(); err != nil {
+				if name == interview.DefaultTemplate {
+					return nil, fmt.Errorf("invalid template %s without coding stage: %w", name, err)
+				}
+
+				log.Printf("warning: template %s is disabled because its plan is empty without the coding stage", name)
+				delete(templates, name)
+
+				continue
+			}
+
+			templates[name] = template
+		}
+
+		if err := questions.Check(templates[name].Plan); err != nil {
+			return nil, fmt.Errorf("invalid template %s: %w", name, err)
+		}
+	}
+
+	return templates, nil
+}
+
+// template digunakan untuk mengambil template berdasarkan nama, kosong berarti template default
+func (h *handler) template(name string) (interview.Template, bool) {
+	h.templatesMu.RLock()
+	defer h.templatesMu.RUnlock()
+
+	return h.templates.Get(name)
+}
+
+func (h *handler) ListTemplates(w http.ResponseWriter, req *http.Request) {
+	h.templatesMu.RLock()
+	templates := h.templates
+	h.templatesMu.RUnlock()
+
+	sendResponse(w, templatesResponse(templates), "success", http.StatusOK)
+}
+
+func (h *handler) ReloadTemplates(w http.ResponseWriter, req *http.Request) {
+	// template lama tetap dipakai jika ada template baru yang tidak valid
+	templates, err := loadTemplates(h.templateDir, h.questions, h.sandbox != nil)
+	if err != nil {
+		log.Printf("failed to reload templates: %v", err)
+		sendResponse(w, nil, fmt.Sprintf("failed to reload templates: %v", err), http.StatusBadRequest)
+
+		return
+	}
+
+	h.templatesMu.Lock()
+	h.templates = templates
+	h.templatesMu.Unlock()
+
+	sendResponse(w, templatesResponse(templates), "success", http.StatusOK)
+}
+
+// templatesResponse digunakan untuk meringkas semua template secara berurutan
+func templatesResponse(templates interview.Templates) []model.Template {
+	response := []model.Template{}
+	for _, name := range templates.Names() {
+		template := templates[name]
+
+		item := model.Template{
+			Name:   template.Name,
+			Title:  template.Title,
+			Stages: []string{},
+		}
+		for _, stage := range template.Plan.Stages {
+			item.Stages = append(item.Stages, string(stage.Stage))
+		}
+		for _, persona := range template.Panel.Personas {
+			item.Panelists = append(item.Panelists, persona.Name)
+		}
+
+		response = append(response, item)
+	}
+
+	return response
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/interview"
+)
+
+// writeTemplate digunakan untuk menulis template dengan plan dari stages ke direktori
+func writeTemplate(t *testing.T, dir, name, stages string) {
+	t.Helper()
+
+	content := `{"name": "` + name + `", "title": "` + name + `", "plan": {"stages": ` + stages + `}}`
+	if err := os.WriteFile(filepath.Join(dir, name+".json"), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+}
+
+func TestLoadTemplatesWithoutCoding(t *testing.T) {
+	const (
+		mixed  = `[{"stage": "intro", "questions": 1}, {"stage": "coding", "questions": 1}]`
+		coding = `[{"stage": "coding", "questions": 1}]`
+	)
+
+	tests := []struct {
+		name      string
+		templates map[string]string
+		coding    bool
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "coding stage is kept when the sandbox is available",
+			templates: map[string]string{interview.DefaultTemplate: mixed, "live-coding": coding},
+			coding:    true,
+			wantNames: []string{interview.DefaultTemplate, "live-coding"},
+		},
+		{
+			name:      "coding-only template is skipped",
+			templates: map[string]string{interview.DefaultTemplate: mixed, "live-coding": coding},
+			wantNames: []string{interview.DefaultTemplate},
+		},
+		{
+			name:      "coding-only default template is rejected",
+			templates: map[string]string{interview.DefaultTemplate: coding},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, stages := range tt.templates {
+				writeTemplate(t, dir, name, stages)
+			}
+
+			templates, err := loadTemplates(dir, interview.Bank{}, tt.coding)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadTemplates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			names := templates.Names()
+			if len(names) != len(tt.wantNames) {
+				t.Fatalf("loadTemplates() = %v, want %v", names, tt.wantNames)
+			}
+			for i, name := range names {
+				if name != tt.wantNames[i] {
+					t.Errorf("loadTemplates() = %v, want %v", names, tt.wantNames)
+				}
+
+				// setiap template yang dimuat harus bisa menentukan tahap pertamanya
+				templates[name].Plan.Current(templates[name].Plan.Start(time.Now()))
+			}
+		})
+	}
+}
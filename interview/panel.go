@@ -0,0 +1,147 @@
+package interview
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// personaID membatasi ID persona karena dipakai sebagai nama pengirim pesan di API chat
+var personaID = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// Persona adalah satu interviewer di mode panel
+type Persona struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Role adalah jabatan persona, misalnya Engineering Manager
+	Role         string `json:"role"`
+	Instructions string `json:"instructions"`
+	// Voice adalah suara TTS persona, kosong berarti suara default
+	Voice string `json:"voice,omitempty"`
+	// Stages adalah tahap yang dipimpin persona ini, kosong berarti semua tahap
+	Stages []Stage `json:"stages,omitempty"`
+}
+
+// Panel berisi persona interviewer, interview dengan lebih dari satu persona berjalan dalam mode panel
+type Panel struct {
+	Personas []Persona `json:"personas,omitempty"`
+}
+
+// Enabled digunakan untuk mengecek apakah template menggunakan mode panel
+func (p Panel) Enabled() bool {
+	return len(p.Personas) > 0
+}
+
+// Validate digunakan untuk memastikan setiap persona bisa dipakai
+func (p Panel) Validate() error {
+	ids := make(map[string]bool)
+	for _, persona := range p.Personas {
+		if !personaID.MatchString(persona.ID) {
+			return fmt.Errorf("panel persona id %q must only contain letters, digits, _ and -", persona.ID)
+		}
+		if ids[persona.ID] {
+			return fmt.Errorf("panel persona %s is duplicated", persona.ID)
+		}
+		ids[persona.ID] = true
+
+		if persona.Name == "" {
+			return fmt.Errorf("panel persona %s name is required", persona.ID)
+		}
+	}
+
+	return nil
+}
+
+// Get digunakan untuk mengambil persona berdasarkan ID
+func (p Panel) Get(id string) (Persona, bool) {
+	for _, persona := range p.Personas {
+		if persona.ID == id {
+			return persona, true
+		}
+	}
+
+	return Persona{}, false
+}
+
+// Lead digunakan untuk mendapatkan persona yang membuka interview
+func (p Panel) Lead() Persona {
+	if !p.Enabled() {
+		return Persona{}
+	}
+
+	return p.Personas[0]
+}
+
+// Next digunakan untuk menentukan persona yang berbicara berikutnya.
+// Persona yang sama melanjutkan jika giliran ini adalah pertanyaan lanjutan,
+// selain itu giliran diberikan ke persona tahap ini yang paling lama tidak berbicara.
+// speakers berisi persona yang berbicara di setiap giliran AI sebelumnya, dari yang paling lama
+func (p Panel) Next(stage Stage, speakers []string, continuing bool) Persona {
+	if !p.Enabled() {
+		return Persona{}
+	}
+
+	var eligible []Persona
+	for _, persona := range p.Personas {
+		if len(persona.Stages) == 0 || slices.Contains(persona.Stages, stage) {
+			eligible = append(eligible, persona)
+		}
+	}
+	if len(eligible) == 0 {
+		eligible = p.Personas
+	}
+
+	last := ""
+	if len(speakers) > 0 {
+		last = speakers[len(speakers)-1]
+	}
+
+	if continuing {
+		for _, persona := range eligible {
+			if persona.ID == last {
+				return persona
+			}
+		}
+	}
+
+	// persona yang belum pernah berbicara didahulukan sesuai urutan di template
+	next, nextSpoke := eligible[0], len(speakers)
+	for _, persona := range eligible {
+		spoke := -1
+		for i := len(speakers) - 1; i >= 0; i-- {
+			if speakers[i] == persona.ID {
+				spoke = i
+				break
+			}
+		}
+
+		if spoke < nextSpoke && (persona.ID != last || len(eligible) == 1) {
+			next, nextSpoke = persona, spoke
+		}
+	}
+
+	return next
+}
+
+// PanelInstruction digunakan untuk memperkenalkan anggota panel ke AI di awal interview
+func PanelInstruction(panel Panel) string {
+	var b strings.Builder
+	b.WriteString("This is a panel interview. You play every interviewer on the panel, but only one of them speaks in each of your messages. The panel members are:\n")
+	for _, persona := range panel.Personas {
+		fmt.Fprintf(&b, "- %s (%s), id %s\n", persona.Name, persona.Role, persona.ID)
+	}
+	b.WriteString("Each of your messages is tagged with the id of the panel member who said it. When a panel member speaks for the first time, they briefly introduce themselves.")
+
+	return b.String()
+}
+
+// PersonaInstruction digunakan untuk memberi tahu AI persona yang berbicara di giliran ini
+func PersonaInstruction(persona Persona) string {
+	instruction := fmt.Sprintf("In this message you speak only as %s (%s). Do not prefix the message with your name.", persona.Name, persona.Role)
+	if persona.Instructions != "" {
+		instruction += " " + persona.Instructions
+	}
+
+	return instruction
+}
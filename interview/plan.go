@@ -0,0 +1,190 @@
+package interview
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Stage string
+
+const (
+	STAGE_INTRO               Stage = "intro"
+	STAGE_EXPERIENCE          Stage = "experience"
+	STAGE_TECHNICAL           Stage = "technical"
+	STAGE_CODING              Stage = "coding"
+	STAGE_SYSTEM_DESIGN       Stage = "system-design"
+	STAGE_BEHAVIORAL          Stage = "behavioral"
+	STAGE_CANDIDATE_QUESTIONS Stage = "candidate-questions"
+	STAGE_WRAP_UP             Stage = "wrap-up"
+)
+
+// StagePlan berisi tujuan dan batas satu tahap interview
+type StagePlan struct {
+	Stage Stage  `json:"stage"`
+	Goal  string `json:"goal"`
+
+	// Questions adalah jumlah pertanyaan maksimal di tahap ini
+	Questions int `json:"questions"`
+	// TimeBudget adalah lama maksimal tahap ini, 0 berarti tanpa batas waktu
+	TimeBudget Duration `json:"time_budget,omitempty"`
+
+	// BankQuestions adalah jumlah pertanyaan dari bank soal yang diajukan di tahap ini,
+	// dipilih dari RequiredQuestions lalu pertanyaan dengan salah satu QuestionTags
+	BankQuestions     int          `json:"bank_questions,omitempty"`
+	QuestionTags      []string     `json:"question_tags,omitempty"`
+	RequiredQuestions []string     `json:"required_questions,omitempty"`
+	Difficulties      []Difficulty `json:"difficulties,omitempty"`
+}
+
+// Plan berisi urutan tahap interview, tahap terakhir menutup interview
+type Plan struct {
+	Stages []StagePlan `json:"stages"`
+}
+
+// Progress menyimpan posisi interview di dalam plan
+type Progress struct {
+	StageIndex     int
+	StageStartedAt time.Time
+	// QuestionsAsked adalah jumlah pertanyaan yang sudah diajukan di tahap saat ini
+	QuestionsAsked int
+	Finished       bool
+	FinishedAt     time.Time `bson:",omitempty"`
+}
+
+// DefaultPlan digunakan jika template tidak memiliki plan
+func DefaultPlan() Plan {
+	return Plan{
+		Stages: []StagePlan{
+			{Stage: STAGE_INTRO, Goal: "Get to know the interviewee and their background.", Questions: 2, TimeBudget: Duration(5 * time.Minute)},
+			{Stage: STAGE_EXPERIENCE, Goal: "Explore the interviewee's professional experience in backend development and Golang.", Questions: 3, TimeBudget: Duration(10 * time.Minute)},
+			{Stage: STAGE_TECHNICAL, Goal: "Ask technical Golang and backend questions and dig into the reasoning behind the answers.", Questions: 4, TimeBudget: Duration(15 * time.Minute)},
+			{Stage: STAGE_BEHAVIORAL, Goal: "Ask behavioral questions about teamwork, conflict, leadership, strengths, and weaknesses.", Questions: 3, TimeBudget: Duration(10 * time.Minute)},
+			{Stage: STAGE_CANDIDATE_QUESTIONS, Goal: "Ask whether the interviewee has any questions for you and answer them briefly.", Questions: 1, TimeBudget: Duration(5 * time.Minute)},
+			{Stage: STAGE_WRAP_UP, Goal: "Give the interviewee feedback on what they did well and what they could improve, then close the interview. Do not ask any more questions.", Questions: 1},
+		},
+	}
+}
+
+// Validate digunakan untuk memastikan plan bisa dijalankan
+func (p Plan) Validate() error {
+	if len(p.Stages) == 0 {
+		return fmt.Errorf("plan must have at least one stage")
+	}
+
+	for _, stage := range p.Stages {
+		if stage.Stage == "" {
+			return fmt.Errorf("plan stage name is required")
+		}
+		if stage.Questions <= 0 {
+			return fmt.Errorf("plan stage %s must have a positive question budget", stage.Stage)
+		}
+		if max(stage.BankQuestions, len(stage.RequiredQuestions)) > stage.Questions {
+			return fmt.Errorf("plan stage %s has more bank questions than its question budget", stage.Stage)
+		}
+	}
+
+	return nil
+}
+
+// Without digunakan untuk membuat plan tanpa tahap tertentu,
+// misalnya tahap coding ketika kode user tidak bisa dijalankan di server
+func (p Plan) Without(stage Stage) Plan {
+	stages := make([]StagePlan, 0, len(p.Stages))
+	for _, s := range p.Stages {
+		if s.Stage != stage {
+			stages = append(stages, s)
+		}
+	}
+
+	return Plan{Stages: stages}
+}
+
+// Start digunakan untuk memulai plan, pertanyaan pembuka dihitung sebagai pertanyaan pertama
+func (p Plan) Start(now time.Time) Progress {
+	return Progress{
+		StageIndex:     0,
+		StageStartedAt: now,
+		QuestionsAsked: 1,
+	}
+}
+
+// Current digunakan untuk mendapatkan tahap dari progress
+func (p Plan) Current(progress Progress) StagePlan {
+	index := progress.StageIndex
+	if index >= len(p.Stages) {
+		index = len(p.Stages) - 1
+	}
+
+	return p.Stages[index]
+}
+
+// Next digunakan untuk menentukan tahap giliran berikutnya,
+// pindah ke tahap selanjutnya jika jumlah pertanyaan atau waktu tahap saat ini sudah habis.
+// Tahap yang kehabisan waktu tetap dilanjutkan selama masih ada pertanyaan wajib yang belum diajukan
+func (p Plan) Next(progress Progress, questions Questions, now time.Time) Progress {
+	if progress.Finished {
+		return progress
+	}
+
+	// chat lama tanpa progress dimulai dari awal
+	if progress.StageStartedAt.IsZero() {
+		progress = p.Start(now)
+	}
+
+	for progress.StageIndex < len(p.Stages)-1 {
+		stage := p.Stages[progress.StageIndex]
+
+		outOfQuestions := progress.QuestionsAsked >= stage.Questions
+		outOfTime := stage.TimeBudget > 0 && now.Sub(progress.StageStartedAt) >= time.Duration(stage.TimeBudget)
+		if !outOfQuestions && !outOfTime {
+			break
+		}
+		if !outOfQuestions && len(questions.PendingRequired(stage)) > 0 {
+			break
+		}
+
+		progress.StageIndex++
+		progress.StageStartedAt = now
+		progress.QuestionsAsked = 0
+	}
+
+	return progress
+}
+
+// Asked digunakan untuk mencatat pertanyaan yang baru diajukan,
+// interview selesai setelah pertanyaan terakhir di tahap terakhir
+func (p Plan) Asked(progress Progress, now time.Time) Progress {
+	progress.QuestionsAsked++
+
+	last := progress.StageIndex >= len(p.Stages)-1
+	if last && progress.QuestionsAsked >= p.Current(progress).Questions {
+		progress.Finished = true
+		progress.FinishedAt = now
+	}
+
+	return progress
+}
+
+// Instruction digunakan untuk membuat instruksi tahap yang dikirim ke AI setiap giliran
+func (p Plan) Instruction(progress Progress) string {
+	stage := p.Current(progress)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Interview plan: you are in stage %d of %d (%s). %s", progress.StageIndex+1, len(p.Stages), stage.Stage, stage.Goal)
+
+	if progress.StageIndex >= len(p.Stages)-1 {
+		b.WriteString(" This is the final stage, so end the interview with this message.")
+		return b.String()
+	}
+
+	remaining := stage.Questions - progress.QuestionsAsked
+	fmt.Fprintf(&b, " You may ask %d more question(s) in this stage.", remaining)
+
+	if remaining <= 1 {
+		next := p.Stages[progress.StageIndex+1]
+		fmt.Fprintf(&b, " After this question the interview moves to the %s stage.", next.Stage)
+	}
+
+	return b.String()
+}
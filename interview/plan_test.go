@@ -0,0 +1,59 @@
+package interview
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextKeepsStageWithPendingRequiredQuestions(t *testing.T) {
+	plan := Plan{
+		Stages: []StagePlan{
+			{Stage: STAGE_TECHNICAL, Questions: 3, TimeBudget: Duration(time.Minute), RequiredQuestions: []string{"required"}},
+			{Stage: STAGE_WRAP_UP, Questions: 1},
+		},
+	}
+
+	now := time.Now()
+	started := Progress{StageStartedAt: now.Add(-2 * time.Minute), QuestionsAsked: 1}
+
+	tests := []struct {
+		name      string
+		progress  Progress
+		questions Questions
+		want      int
+	}{
+		{
+			name:      "required question pending after time budget",
+			progress:  started,
+			questions: Questions{{ID: "required", Stage: STAGE_TECHNICAL}},
+			want:      0,
+		},
+		{
+			name:      "required question already asked",
+			progress:  started,
+			questions: Questions{{ID: "required", Stage: STAGE_TECHNICAL, Asked: true}},
+			want:      1,
+		},
+		{
+			name:      "only optional question pending",
+			progress:  started,
+			questions: Questions{{ID: "optional", Stage: STAGE_TECHNICAL}},
+			want:      1,
+		},
+		{
+			name:      "question budget used up",
+			progress:  Progress{StageStartedAt: now, QuestionsAsked: 3},
+			questions: Questions{{ID: "required", Stage: STAGE_TECHNICAL}},
+			want:      1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := plan.Next(tt.progress, tt.questions, now)
+			if got.StageIndex != tt.want {
+				t.Errorf("StageIndex = %d, want %d", got.StageIndex, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package interview
+
+import (
+	"fmt"
+	"slices"
+)
+
+type Decision string
+
+const (
+	DECISION_PROBE   Decision = "probe"
+	DECISION_MOVE_ON Decision = "move-on"
+)
+
+// Policy menentukan apakah interviewer menggali jawaban lebih dalam atau pindah ke pertanyaan lain
+// setelah setiap jawaban. Nilai rubric tidak dipakai karena jawaban baru dinilai setelah gilirannya disimpan
+type Policy struct {
+	// ShortAnswerWords adalah jumlah kata di bawahnya jawaban dianggap terlalu singkat dan perlu digali
+	ShortAnswerWords int `json:"short_answer_words"`
+	// MaxFollowUps adalah jumlah pertanyaan lanjutan berturut-turut sebelum pindah ke pertanyaan lain
+	MaxFollowUps int `json:"max_follow_ups"`
+	// Stages adalah tahap yang menggunakan policy, kosong berarti semua tahap
+	Stages []Stage `json:"stages,omitempty"`
+}
+
+// Signals berisi data jawaban terakhir yang dipakai policy untuk mengambil keputusan
+type Signals struct {
+	AnswerWords int
+	// FollowUps adalah jumlah pertanyaan lanjutan berturut-turut untuk pertanyaan saat ini
+	FollowUps int
+}
+
+// DefaultPolicy digunakan jika template tidak memiliki policy
+func DefaultPolicy() Policy {
+	return Policy{
+		ShortAnswerWords: 25,
+		MaxFollowUps:     2,
+		Stages:           []Stage{STAGE_EXPERIENCE, STAGE_TECHNICAL, STAGE_CODING, STAGE_SYSTEM_DESIGN, STAGE_BEHAVIORAL},
+	}
+}
+
+// Validate digunakan untuk memastikan batas policy masuk akal
+func (p Policy) Validate() error {
+	if p.ShortAnswerWords < 0 || p.MaxFollowUps < 0 {
+		return fmt.Errorf("policy short_answer_words and max_follow_ups must not be negative")
+	}
+
+	return nil
+}
+
+// Applies digunakan untuk mengecek apakah policy dipakai di tahap tertentu
+func (p Policy) Applies(stage Stage) bool {
+	return len(p.Stages) == 0 || slices.Contains(p.Stages, stage)
+}
+
+// Decide digunakan untuk menentukan langkah interviewer berikutnya dari jawaban terakhir
+func (p Policy) Decide(signals Signals) Decision {
+	// jawaban yang terlalu singkat digali sampai batas pertanyaan lanjutan
+	if signals.FollowUps < p.MaxFollowUps && signals.AnswerWords < p.ShortAnswerWords {
+		return DECISION_PROBE
+	}
+
+	return DECISION_MOVE_ON
+}
+
+// PolicyInstruction digunakan untuk membuat instruksi tersembunyi berisi keputusan policy untuk AI
+func PolicyInstruction(decision Decision) string {
+	switch decision {
+	case DECISION_PROBE:
+		return "Interview policy: do not move to a new topic yet. Ask one follow-up question that digs deeper into the interviewee's last answer, for example the reasoning, a concrete example, or a missing detail."
+	case DECISION_MOVE_ON:
+		return "Interview policy: the last topic has been covered enough. Move on to a new question."
+	default:
+		return ""
+	}
+}
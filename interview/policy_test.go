@@ -0,0 +1,57 @@
+package interview
+
+import "testing"
+
+func TestDecide(t *testing.T) {
+	policy := DefaultPolicy()
+
+	tests := []struct {
+		name    string
+		signals Signals
+		want    Decision
+	}{
+		{
+			name:    "short answer is probed",
+			signals: Signals{AnswerWords: 5},
+			want:    DECISION_PROBE,
+		},
+		{
+			name:    "short answer moves on after max follow-ups",
+			signals: Signals{AnswerWords: 5, FollowUps: 2},
+			want:    DECISION_MOVE_ON,
+		},
+		{
+			name:    "long answer moves on",
+			signals: Signals{AnswerWords: 50},
+			want:    DECISION_MOVE_ON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Decide(tt.signals); got != tt.want {
+				t.Errorf("Decide() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  Policy
+		wantErr bool
+	}{
+		{name: "default", policy: DefaultPolicy()},
+		{name: "negative follow-ups", policy: Policy{MaxFollowUps: -1}, wantErr: true},
+		{name: "negative short answer words", policy: Policy{ShortAnswerWords: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package interview
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Difficulty string
+
+const (
+	DIFFICULTY_EASY   Difficulty = "easy"
+	DIFFICULTY_MEDIUM Difficulty = "medium"
+	DIFFICULTY_HARD   Difficulty = "hard"
+)
+
+// Question adalah satu pertanyaan di bank soal
+type Question struct {
+	ID         string     `json:"id" yaml:"id"`
+	Text       string     `json:"text" yaml:"text"`
+	Tags       []string   `json:"tags" yaml:"tags"`
+	Difficulty Difficulty `json:"difficulty" yaml:"difficulty"`
+	// KeyPoints adalah poin yang diharapkan muncul di jawaban
+	KeyPoints []string `json:"key_points" yaml:"key_points"`
+
+	// StarterCode dan Tests hanya diisi untuk soal coding, Tests adalah file test
+	// tersembunyi di package solution yang dijalankan terhadap kode user
+	StarterCode string `json:"starter_code,omitempty" yaml:"starter_code,omitempty"`
+	Tests       string `json:"tests,omitempty" yaml:"tests,omitempty"`
+}
+
+// IsCoding digunakan untuk mengecek apakah pertanyaan adalah soal coding
+func (q Question) IsCoding() bool {
+	return q.Tests != ""
+}
+
+// questionFile adalah isi satu file bank soal
+type questionFile struct {
+	Questions []Question `json:"questions" yaml:"questions"`
+}
+
+// Bank berisi semua pertanyaan berdasarkan ID
+type Bank map[string]Question
+
+// LoadBank digunakan untuk membaca semua file bank soal YAML dan JSON dari direktori,
+// direktori yang tidak ada menghasilkan bank kosong
+func LoadBank(dir string) (Bank, error) {
+	var files []string
+	for _, pattern := range []string{"*.yaml", "*.yml", "*.json"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+
+	bank := make(Bank)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var parsed questionFile
+		if filepath.Ext(file) == ".json" {
+			err = json.Unmarshal(content, &parsed)
+		} else {
+			err = yaml.Unmarshal(content, &parsed)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid question file %s: %w", file, err)
+		}
+
+		for _, question := range parsed.Questions {
+			if question.ID == "" || question.Text == "" {
+				return nil, fmt.Errorf("invalid question file %s: id and text are required", file)
+			}
+			if _, ok := bank[question.ID]; ok {
+				return nil, fmt.Errorf("invalid question file %s: duplicate question %s", file, question.ID)
+			}
+			if question.Difficulty == "" {
+				question.Difficulty = DIFFICULTY_MEDIUM
+			}
+
+			bank[question.ID] = question
+		}
+	}
+
+	return bank, nil
+}
+
+// Check digunakan untuk memastikan semua pertanyaan wajib di plan ada di bank soal
+func (b Bank) Check(plan Plan) error {
+	for _, stage := range plan.Stages {
+		for _, id := range stage.RequiredQuestions {
+			if _, ok := b[id]; !ok {
+				return fmt.Errorf("question %s required by stage %s not found", id, stage.Stage)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Sample digunakan untuk memilih pertanyaan setiap tahap di plan,
+// pertanyaan wajib didahulukan lalu sisanya dipilih acak berdasarkan tag dan tingkat kesulitan
+func (b Bank) Sample(plan Plan) Questions {
+	// urutkan ID agar hasil acak hanya bergantung pada rand
+	ids := make([]string, 0, len(b))
+	for id := range b {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	picked := make(map[string]bool)
+	var questions Questions
+
+	for _, stage := range plan.Stages {
+		count := max(stage.BankQuestions, len(stage.RequiredQuestions))
+
+		var selected []Question
+		for _, id := range stage.RequiredQuestions {
+			if question, ok := b[id]; ok && !picked[id] {
+				selected = append(selected, question)
+				picked[id] = true
+			}
+		}
+
+		var candidates []Question
+		for _, id := range ids {
+			question := b[id]
+			if !picked[id] && stage.matches(question) {
+				candidates = append(candidates, question)
+			}
+		}
+		rand.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+
+		for _, question := range candidates {
+			if len(selected) >= count {
+				break
+			}
+			selected = append(selected, question)
+			picked[question.ID] = true
+		}
+
+		for _, question := range selected {
+			questions = append(questions, SessionQuestion{
+				ID:          question.ID,
+				Stage:       stage.Stage,
+				Text:        question.Text,
+				Difficulty:  question.Difficulty,
+				KeyPoints:   question.KeyPoints,
+				Coding:      question.IsCoding(),
+				StarterCode: question.StarterCode,
+			})
+		}
+	}
+
+	return questions
+}
+
+// matches digunakan untuk mengecek apakah pertanyaan cocok dengan tag dan tingkat kesulitan tahap
+func (s StagePlan) matches(question Question) bool {
+	if len(s.Difficulties) > 0 && !slices.Contains(s.Difficulties, question.Difficulty) {
+		return false
+	}
+
+	for _, tag := range question.Tags {
+		if slices.Contains(s.QuestionTags, tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SessionQuestion adalah salinan pertanyaan bank soal yang dipilih untuk satu chat
+// beserta status pengajuan dan poin jawaban yang sudah dibahas
+type SessionQuestion struct {
+	ID         string
+	Stage      Stage
+	Text       string
+	Difficulty Difficulty
+	KeyPoints  []string
+
+	// Coding bernilai true untuk soal coding, test tersembunyi tetap dibaca dari bank soal
+	Coding      bool   `bson:",omitempty"`
+	StarterCode string `bson:",omitempty"`
+
+	Asked   bool
+	AskedAt time.Time `bson:",omitempty"`
+
+	// Evaluated bernilai true setelah jawaban dinilai, Covered berisi poin yang dibahas
+	Evaluated bool
+	Covered   []string `bson:",omitempty"`
+}
+
+// Questions berisi pertanyaan bank soal milik satu chat sesuai urutan tahap
+type Questions []SessionQuestion
+
+// Get digunakan untuk mengambil pertanyaan berdasarkan ID
+func (q Questions) Get(id string) (SessionQuestion, bool) {
+	for _, question := range q {
+		if question.ID == id {
+			return question, true
+		}
+	}
+
+	return SessionQuestion{}, false
+}
+
+// Pending digunakan untuk mendapatkan pertanyaan tahap yang belum diajukan
+func (q Questions) Pending(stage Stage) Questions {
+	var pending Questions
+	for _, question := range q {
+		if question.Stage == stage && !question.Asked {
+			pending = append(pending, question)
+		}
+	}
+
+	return pending
+}
+
+// PendingRequired digunakan untuk mendapatkan pertanyaan wajib tahap yang belum diajukan
+func (q Questions) PendingRequired(stage StagePlan) Questions {
+	var pending Questions
+	for _, question := range q.Pending(stage.Stage) {
+		if slices.Contains(stage.RequiredQuestions, question.ID) {
+			pending = append(pending, question)
+		}
+	}
+
+	return pending
+}
+
+// MarkAsked digunakan untuk mencatat pertanyaan yang sudah diajukan
+func (q Questions) MarkAsked(id string, now time.Time) {
+	for i := range q {
+		if q[i].ID == id && !q[i].Asked {
+			q[i].Asked = true
+			q[i].AskedAt = now
+		}
+	}
+}
+
+// SetCoverage digunakan untuk menambahkan poin jawaban yang sudah dibahas,
+// poin yang dibahas di jawaban sebelumnya tetap dihitung
+func (q Questions) SetCoverage(id string, covered []string) {
+	for i := range q {
+		if q[i].ID != id {
+			continue
+		}
+
+		q[i].Evaluated = true
+		q[i].Covered = slices.Clip(q[i].Covered)
+		for _, point := range covered {
+			if !slices.Contains(q[i].Covered, point) {
+				q[i].Covered = append(q[i].Covered, point)
+			}
+		}
+	}
+}
+
+// LastCoding digunakan untuk mendapatkan soal coding terakhir yang sudah diajukan
+func (q Questions) LastCoding() (SessionQuestion, bool) {
+	var last SessionQuestion
+	found := false
+	for _, question := range q {
+		if question.Coding && question.Asked && (!found || question.AskedAt.After(last.AskedAt)) {
+			last = question
+			found = true
+		}
+	}
+
+	return last, found
+}
+
+// NextQuestion digunakan untuk memilih pertanyaan bank soal yang harus diajukan giliran ini
+// berdasarkan keputusan policy. Jika policy memutuskan untuk menggali jawaban dan sisa kuota tahap
+// masih lebih banyak dari pertanyaan yang tersisa, giliran ini dibiarkan kosong untuk pertanyaan lanjutan
+func (p Plan) NextQuestion(progress Progress, questions Questions, decision Decision) (SessionQuestion, bool) {
+	stage := p.Current(progress)
+
+	pending := questions.Pending(stage.Stage)
+	if len(pending) == 0 {
+		return SessionQuestion{}, false
+	}
+
+	// pertanyaan bank soal dari tahap sebelumnya tidak perlu digali di tahap baru
+	remaining := stage.Questions - progress.QuestionsAsked
+	if decision == DECISION_PROBE && progress.QuestionsAsked > 0 && len(pending) < remaining {
+		return SessionQuestion{}, false
+	}
+
+	return pending[0], true
+}
+
+// QuestionInstruction digunakan untuk membuat instruksi pertanyaan bank soal yang harus diajukan
+func QuestionInstruction(question SessionQuestion) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Ask this question from the question bank next, in your own words and in the interview language: %q", question.Text)
+
+	if question.Coding {
+		b.WriteString(" This is a coding problem: tell the interviewee to write the solution in the code editor and submit it, and do not give away the solution.")
+	}
+
+	if len(question.KeyPoints) > 0 {
+		fmt.Fprintf(&b, " Do not reveal the expected key points: %s.", strings.Join(question.KeyPoints, "; "))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,162 @@
+package interview
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// Level adalah satu tingkat kemampuan beserta contoh perilaku yang menandainya
+type Level struct {
+	Score  int    `json:"score"`
+	Name   string `json:"name"`
+	Anchor string `json:"anchor"`
+}
+
+// Competency adalah kemampuan yang dinilai dari jawaban user
+type Competency struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Weight adalah bobot kemampuan di nilai keseluruhan, 0 dianggap 1
+	Weight float64 `json:"weight,omitempty"`
+	// Stages adalah tahap yang jawabannya dinilai untuk kemampuan ini, kosong berarti semua tahap
+	Stages []Stage `json:"stages,omitempty"`
+	Levels []Level `json:"levels"`
+}
+
+// Rubric berisi kemampuan yang dinilai di satu template
+type Rubric struct {
+	Competencies []Competency `json:"competencies"`
+}
+
+// DefaultRubric digunakan jika template tidak memiliki rubric
+func DefaultRubric() Rubric {
+	return Rubric{
+		Competencies: []Competency{
+			{
+				ID:          "technical-knowledge",
+				Name:        "Technical knowledge",
+				Description: "Understanding of Golang and backend development concepts.",
+				Stages:      []Stage{STAGE_EXPERIENCE, STAGE_TECHNICAL, STAGE_CODING, STAGE_SYSTEM_DESIGN},
+				Levels: []Level{
+					{Score: 1, Name: "Limited", Anchor: "Answers are incorrect or only repeat buzzwords without explanation."},
+					{Score: 2, Name: "Developing", Anchor: "Knows the basics but misses important details or makes notable mistakes."},
+					{Score: 3, Name: "Proficient", Anchor: "Explains concepts correctly with practical examples."},
+					{Score: 4, Name: "Strong", Anchor: "Explains concepts in depth, including internals, tradeoffs, and edge cases."},
+				},
+			},
+			{
+				ID:          "problem-solving",
+				Name:        "Problem solving",
+				Description: "Ability to break down problems and reason about solutions and tradeoffs.",
+				Stages:      []Stage{STAGE_TECHNICAL, STAGE_CODING, STAGE_SYSTEM_DESIGN},
+				Levels: []Level{
+					{Score: 1, Name: "Limited", Anchor: "Cannot structure an approach even with hints."},
+					{Score: 2, Name: "Developing", Anchor: "Reaches a partial approach but misses edge cases or tradeoffs."},
+					{Score: 3, Name: "Proficient", Anchor: "Structures a working approach and considers main edge cases."},
+					{Score: 4, Name: "Strong", Anchor: "Compares alternatives, justifies tradeoffs, and anticipates failure cases."},
+				},
+			},
+			{
+				ID:          "communication",
+				Name:        "Communication",
+				Description: "Clarity and structure of the answers.",
+				Levels: []Level{
+					{Score: 1, Name: "Limited", Anchor: "Answers are hard to follow or do not address the question."},
+					{Score: 2, Name: "Developing", Anchor: "Answers the question but is unstructured or vague."},
+					{Score: 3, Name: "Proficient", Anchor: "Answers clearly and concisely with a logical structure."},
+					{Score: 4, Name: "Strong", Anchor: "Answers are precise, well structured, and adapted to the listener."},
+				},
+			},
+			{
+				ID:          "collaboration",
+				Name:        "Collaboration",
+				Description: "Teamwork, ownership, and handling conflict.",
+				Stages:      []Stage{STAGE_EXPERIENCE, STAGE_BEHAVIORAL},
+				Levels: []Level{
+					{Score: 1, Name: "Limited", Anchor: "Blames others or gives no concrete example."},
+					{Score: 2, Name: "Developing", Anchor: "Gives an example but their own role or the outcome is unclear."},
+					{Score: 3, Name: "Proficient", Anchor: "Describes a concrete situation, their own actions, and the outcome."},
+					{Score: 4, Name: "Strong", Anchor: "Shows ownership, reflects on lessons learned, and helped others succeed."},
+				},
+			},
+		},
+	}
+}
+
+// Validate digunakan untuk memastikan rubric bisa dipakai untuk menilai
+func (r Rubric) Validate() error {
+	if len(r.Competencies) == 0 {
+		return fmt.Errorf("rubric must have at least one competency")
+	}
+
+	ids := make(map[string]bool)
+	for _, competency := range r.Competencies {
+		if competency.ID == "" {
+			return fmt.Errorf("rubric competency id is required")
+		}
+		if ids[competency.ID] {
+			return fmt.Errorf("rubric competency %s is duplicated", competency.ID)
+		}
+		ids[competency.ID] = true
+
+		if competency.Weight < 0 {
+			return fmt.Errorf("rubric competency %s must not have a negative weight", competency.ID)
+		}
+		if len(competency.Levels) < 2 {
+			return fmt.Errorf("rubric competency %s must have at least two levels", competency.ID)
+		}
+
+		// level harus bernilai 1 sampai N secara berurutan agar nilai bisa dibandingkan
+		for i, level := range competency.Levels {
+			if level.Score != i+1 {
+				return fmt.Errorf("rubric competency %s levels must be scored 1 to %d in order", competency.ID, len(competency.Levels))
+			}
+		}
+	}
+
+	return nil
+}
+
+// Version digunakan untuk mendapatkan sidik rubric, nilai hanya bisa dibandingkan
+// antar chat yang dinilai dengan versi rubric yang sama
+func (r Rubric) Version() string {
+	content, _ := json.Marshal(r)
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:6])
+}
+
+// ForStage digunakan untuk mendapatkan kemampuan yang dinilai di tahap tertentu
+func (r Rubric) ForStage(stage Stage) []Competency {
+	var competencies []Competency
+	for _, competency := range r.Competencies {
+		if competency.assessedIn(stage) {
+			competencies = append(competencies, competency)
+		}
+	}
+
+	return competencies
+}
+
+// Get digunakan untuk mengambil kemampuan berdasarkan ID
+func (r Rubric) Get(id string) (Competency, bool) {
+	for _, competency := range r.Competencies {
+		if competency.ID == id {
+			return competency, true
+		}
+	}
+
+	return Competency{}, false
+}
+
+// weight digunakan untuk mendapatkan bobot kemampuan, bobot kosong dianggap 1
+func (c Competency) weight() float64 {
+	if c.Weight == 0 {
+		return 1
+	}
+
+	return c.Weight
+}
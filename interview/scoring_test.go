@@ -0,0 +1,192 @@
+package interview
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+)
+
+// fakeClient adalah ai.Client yang membalas StructuredChat dengan JSON tetap atau err jika diisi,
+// method lain tidak dipakai di test ini
+type fakeClient struct {
+	ai.Client
+	structured string
+	err        error
+}
+
+func (f fakeClient) StructuredChat([]ai.ChatMessage, ai.JSONSchema) (ai.ChatResponse, error) {
+	if f.err != nil {
+		return ai.ChatResponse{}, f.err
+	}
+
+	return ai.ChatResponse{
+		Choices: []ai.Choice{{Message: ai.ChatMessage{Role: ai.ROLE_ASSISTANT, Content: f.structured}}},
+	}, nil
+}
+
+// testRubric berisi kemampuan dengan jumlah level, bobot, dan tahap yang berbeda
+func testRubric() Rubric {
+	levels := func(n int) []Level {
+		var result []Level
+		for i := 1; i <= n; i++ {
+			result = append(result, Level{Score: i})
+		}
+		return result
+	}
+
+	return Rubric{
+		Competencies: []Competency{
+			{ID: "design", Name: "Design", Weight: 3, Stages: []Stage{STAGE_TECHNICAL}, Levels: levels(5)},
+			{ID: "clarity", Name: "Clarity", Levels: levels(3)},
+			{ID: "teamwork", Name: "Teamwork", Weight: 2, Stages: []Stage{STAGE_BEHAVIORAL}, Levels: levels(2)},
+		},
+	}
+}
+
+func TestScoreAnswer(t *testing.T) {
+	rubric := testRubric()
+
+	tests := []struct {
+		name       string
+		rubric     Rubric
+		stage      Stage
+		structured string
+		err        error
+		want       []Score
+		wantErr    bool
+	}{
+		{
+			name:       "scores within the stage",
+			rubric:     rubric,
+			stage:      STAGE_TECHNICAL,
+			structured: `{"scores":[{"competency":"design","level":5,"evidence":" layered services "},{"competency":"clarity","level":2,"evidence":"structured"}]}`,
+			want: []Score{
+				{Competency: "design", Level: 5, Evidence: "layered services"},
+				{Competency: "clarity", Level: 2, Evidence: "structured"},
+			},
+		},
+		{
+			name:       "levels outside the rubric are ignored",
+			rubric:     rubric,
+			stage:      STAGE_TECHNICAL,
+			structured: `{"scores":[{"competency":"design","level":6,"evidence":"too high"},{"competency":"design","level":0,"evidence":"too low"},{"competency":"clarity","level":3,"evidence":"clear"}]}`,
+			want:       []Score{{Competency: "clarity", Level: 3, Evidence: "clear"}},
+		},
+		{
+			name:       "repeated competency keeps the first score",
+			rubric:     rubric,
+			stage:      STAGE_TECHNICAL,
+			structured: `{"scores":[{"competency":"design","level":4,"evidence":"first"},{"competency":"design","level":2,"evidence":"second"}]}`,
+			want:       []Score{{Competency: "design", Level: 4, Evidence: "first"}},
+		},
+		{
+			name:       "competencies outside the stage or the rubric are ignored",
+			rubric:     rubric,
+			stage:      STAGE_TECHNICAL,
+			structured: `{"scores":[{"competency":"teamwork","level":2,"evidence":"pairing"},{"competency":"leadership","level":3,"evidence":"led"}]}`,
+		},
+		{
+			name:   "stage without competencies is not scored",
+			rubric: Rubric{Competencies: []Competency{rubric.Competencies[2]}},
+			stage:  STAGE_TECHNICAL,
+			// error berarti AI tidak boleh dipanggil
+			err: errors.New("unexpected call"),
+		},
+		{
+			name:    "AI error",
+			rubric:  rubric,
+			stage:   STAGE_TECHNICAL,
+			err:     errors.New("rate limited"),
+			wantErr: true,
+		},
+		{
+			name:       "invalid AI response",
+			rubric:     rubric,
+			stage:      STAGE_TECHNICAL,
+			structured: `{"scores":`,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fakeClient{structured: tt.structured, err: tt.err}
+
+			scores, err := ScoreAnswer(client, tt.rubric, tt.stage, "How would you design it?", "answer")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ScoreAnswer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(scores, tt.want) {
+				t.Errorf("ScoreAnswer() = %+v, want %+v", scores, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	rubric := testRubric()
+
+	scorecard := Aggregate(rubric, [][]Score{
+		{{Competency: "design", Level: 5}, {Competency: "clarity", Level: 1}},
+		nil,
+		{{Competency: "design", Level: 4}, {Competency: "clarity", Level: 2}},
+	})
+
+	want := Scorecard{
+		RubricVersion: rubric.Version(),
+		// design (4.5 - 1) / 4 = 0.875 dengan bobot 3, clarity (1.5 - 1) / 2 = 0.25 dengan bobot 1
+		Overall: 0.72,
+		Competencies: []CompetencyScore{
+			{ID: "design", Name: "Design", Average: 4.5, MaxLevel: 5, Count: 2},
+			{ID: "clarity", Name: "Clarity", Average: 1.5, MaxLevel: 3, Count: 2},
+			{ID: "teamwork", Name: "Teamwork", MaxLevel: 2},
+		},
+		ScoredTurns: 2,
+	}
+
+	if !reflect.DeepEqual(scorecard, want) {
+		t.Errorf("Aggregate() = %+v, want %+v", scorecard, want)
+	}
+
+	if empty := Aggregate(rubric, nil); empty.Overall != 0 || empty.ScoredTurns != 0 {
+		t.Errorf("Aggregate(nil) = %+v, want no score", empty)
+	}
+}
+
+func TestOverride(t *testing.T) {
+	rubric := testRubric()
+	scorecard := Aggregate(rubric, [][]Score{
+		{{Competency: "design", Level: 5}, {Competency: "clarity", Level: 1}},
+		{{Competency: "design", Level: 4}, {Competency: "clarity", Level: 2}},
+	})
+
+	tests := []struct {
+		name   string
+		levels map[string]int
+		want   float64
+	}{
+		{name: "no override", want: 0.72},
+		// clarity (3 - 1) / 2 = 1 menggantikan rata-rata AI 0.25
+		{name: "scored competency", levels: map[string]int{"clarity": 3}, want: 0.91},
+		// teamwork belum dinilai AI, (2 - 1) / 1 = 1 dengan bobot 2 ikut dihitung
+		{name: "unscored competency", levels: map[string]int{"teamwork": 2}, want: 0.81},
+		{name: "unknown competency", levels: map[string]int{"leadership": 4}, want: 0.72},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scorecard.Override(rubric, tt.levels)
+			if got.Overall != tt.want {
+				t.Errorf("Override() overall = %v, want %v", got.Overall, tt.want)
+			}
+
+			// rata-rata AI tetap dipertahankan
+			if !reflect.DeepEqual(got.Competencies, scorecard.Competencies) {
+				t.Errorf("Override() competencies = %+v, want %+v", got.Competencies, scorecard.Competencies)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package interview
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DefaultTemplate adalah template yang dipakai jika user tidak memilih template
+const DefaultTemplate = "backend-golang"
+
+// Template berisi konfigurasi satu jenis interview
+type Template struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+
+	// Retention menggantikan masa simpan chat default untuk template ini
+	Retention Duration `json:"retention,omitempty"`
+
+	// Plan berisi tahap interview, DefaultPlan dipakai jika kosong
+	Plan Plan `json:"plan"`
+
+	// Rubric berisi kemampuan yang dinilai dari jawaban user, DefaultRubric dipakai jika kosong
+	Rubric Rubric `json:"rubric"`
+
+	// Policy menentukan kapan interviewer menggali jawaban atau pindah pertanyaan, DefaultPolicy dipakai jika kosong
+	Policy *Policy `json:"policy,omitempty"`
+
+	// Panel berisi persona interviewer untuk mode panel, kosong berarti satu interviewer
+	Panel Panel `json:"panel"`
+}
+
+// Templates berisi semua template berdasarkan nama
+type Templates map[string]Template
+
+// LoadTemplates digunakan untuk membaca semua file template JSON dari direktori
+func LoadTemplates(dir string) (Templates, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make(Templates)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var template Template
+		if err := json.Unmarshal(content, &template); err != nil {
+			return nil, fmt.Errorf("invalid template %s: %w", file, err)
+		}
+
+		if template.Name == "" {
+			return nil, fmt.Errorf("invalid template %s: name is required", file)
+		}
+
+		if len(template.Plan.Stages) == 0 {
+			template.Plan = DefaultPlan()
+		}
+
+		if err := template.Plan.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid template %s: %w", file, err)
+		}
+
+		if len(template.Rubric.Competencies) == 0 {
+			template.Rubric = DefaultRubric()
+		}
+
+		if err := template.Rubric.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid template %s: %w", file, err)
+		}
+
+		if template.Policy == nil {
+			policy := DefaultPolicy()
+			template.Policy = &policy
+		}
+
+		if err := template.Policy.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid template %s: %w", file, err)
+		}
+
+		if err := template.Panel.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid template %s: %w", file, err)
+		}
+
+		templates[template.Name] = template
+	}
+
+	if _, ok := templates[DefaultTemplate]; !ok {
+		return nil, fmt.Errorf("default template %s not found in %s", DefaultTemplate, dir)
+	}
+
+	return templates, nil
+}
+
+// Get digunakan untuk mengambil template berdasarkan nama, kosong berarti template default
+func (t Templates) Get(name string) (Template, bool) {
+	if name == "" {
+		name = DefaultTemplate
+	}
+
+	template, ok := t[name]
+	return template, ok
+}
+
+// Names digunakan untuk mendapatkan nama semua template secara berurutan
+func (t Templates) Names() []string {
+	names := make([]string, 0, len(t))
+	for name := range t {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Duration adalah time.Duration yang ditulis sebagai string (misal "720h") di JSON
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
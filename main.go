@@ -6,14 +6,49 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fastcampus-backend-golang/ai-interview/handler"
+	"github.com/fastcampus-backend-golang/ai-interview/redact"
+	"github.com/fastcampus-backend-golang/ai-interview/sandbox"
+	"github.com/fastcampus-backend-golang/ai-interview/share"
 )
 
 var (
-	port   = os.Getenv("PORT")
-	apiKey = os.Getenv("OPENAI_API_KEY")
-	dbURI  = os.Getenv("DB_URI")
+	port         = os.Getenv("PORT")
+	apiKey       = os.Getenv("OPENAI_API_KEY")
+	dbDriver     = os.Getenv("DB_DRIVER")
+	dbURI        = os.Getenv("DB_URI")
+	ttsCacheDir  = os.Getenv("TTS_CACHE_DIR")
+	ttsCacheSize = os.Getenv("TTS_CACHE_SIZE")
+	blobStore    = os.Getenv("BLOB_STORE")
+	blobDir      = os.Getenv("BLOB_DIR")
+
+	recordingRetention = os.Getenv("RECORDING_RETENTION")
+	chatTTL            = os.Getenv("CHAT_TTL")
+	templateDir        = os.Getenv("TEMPLATE_DIR")
+	questionDir        = os.Getenv("QUESTION_DIR")
+	sandboxGo          = os.Getenv("SANDBOX_GO")
+	sandboxCacheDir    = os.Getenv("SANDBOX_CACHE_DIR")
+	sandboxTimeout     = os.Getenv("SANDBOX_TIMEOUT")
+	sandboxCPU         = os.Getenv("SANDBOX_CPU")
+	sandboxMemory      = os.Getenv("SANDBOX_MEMORY")
+	encryptionKeys     = os.Getenv("ENCRYPTION_KEYS")
+	shareKey           = os.Getenv("SHARE_KEY")
+	adminTokens        = os.Getenv("ADMIN_TOKENS")
+	redaction          = os.Getenv("REDACTION")
+	guardModerator     = os.Getenv("GUARD_MODERATOR")
+	guardInjection     = os.Getenv("GUARD_INJECTION_ACTION")
+)
+
+var (
+	redactionRules          []string
+	ttsCacheCapacity        int
+	recordingRetentionValue = 30 * 24 * time.Hour
+	chatTTLValue            time.Duration
+	sandboxLimits           = sandbox.DefaultLimits
 )
 
 func main() {
@@ -22,8 +57,40 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// samarkan data pribadi di semua log
+	redactor, err := redact.New(redactionRules)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.SetOutput(redact.NewWriter(os.Stderr, redactor))
+
 	// buat handler
-	router := handler.NewHandler(apiKey, dbURI)
+	router := handler.NewHandler(handler.Config{
+		APIKey:       apiKey,
+		DBDriver:     dbDriver,
+		DBURI:        dbURI,
+		TTSCacheDir:  ttsCacheDir,
+		TTSCacheSize: ttsCacheCapacity,
+		BlobStore:    blobStore,
+		BlobDir:      blobDir,
+		TemplateDir:  templateDir,
+		QuestionDir:  questionDir,
+
+		SandboxGo:       sandboxGo,
+		SandboxCacheDir: sandboxCacheDir,
+		SandboxLimits:   sandboxLimits,
+
+		EncryptionKeys: encryptionKeys,
+		ShareKey:       shareKey,
+		AdminTokens:    adminTokens,
+		Redaction:      redactionRules,
+
+		GuardModerator:       guardModerator,
+		GuardInjectionAction: guardInjection,
+
+		ChatTTL:            chatTTLValue,
+		RecordingRetention: recordingRetentionValue,
+	})
 
 	// buat server
 	server := &http.Server{
@@ -44,7 +111,15 @@ func validateEnv() error {
 		port = "8080"
 	}
 
-	if dbURI == "" {
+	if dbDriver == "" {
+		dbDriver = handler.DB_DRIVER_MONGO
+	}
+
+	if dbDriver != handler.DB_DRIVER_MONGO && dbDriver != handler.DB_DRIVER_MEMORY {
+		return errors.New("DB_DRIVER must be either mongo or memory")
+	}
+
+	if dbDriver == handler.DB_DRIVER_MONGO && dbURI == "" {
 		return errors.New("DB_URI is required")
 	}
 
@@ -52,5 +127,119 @@ func validateEnv() error {
 		return errors.New("OPENAI_API_KEY is required")
 	}
 
+	if ttsCacheDir == "" {
+		ttsCacheDir = "cache/tts"
+	}
+
+	if ttsCacheSize != "" {
+		size, err := strconv.Atoi(ttsCacheSize)
+		if err != nil || size <= 0 {
+			return errors.New("TTS_CACHE_SIZE must be a positive number")
+		}
+		ttsCacheCapacity = size
+	}
+
+	if blobStore == "" && dbDriver == handler.DB_DRIVER_MEMORY {
+		blobStore = handler.BLOB_STORE_FS
+	}
+
+	if blobStore == "" {
+		blobStore = handler.BLOB_STORE_GRIDFS
+	}
+
+	if blobStore != handler.BLOB_STORE_GRIDFS && blobStore != handler.BLOB_STORE_FS {
+		return errors.New("BLOB_STORE must be either gridfs or fs")
+	}
+
+	if blobStore == handler.BLOB_STORE_GRIDFS && dbDriver != handler.DB_DRIVER_MONGO {
+		return errors.New("BLOB_STORE gridfs requires DB_DRIVER mongo")
+	}
+
+	if blobDir == "" {
+		blobDir = "storage/audio"
+	}
+
+	if recordingRetention != "" {
+		retention, err := time.ParseDuration(recordingRetention)
+		if err != nil || retention <= 0 {
+			return errors.New("RECORDING_RETENTION must be a positive duration")
+		}
+		recordingRetentionValue = retention
+	}
+
+	// CHAT_TTL kosong atau 0 berarti chat disimpan tanpa batas waktu seperti sebelum ada retensi
+	if chatTTL != "" {
+		ttl, err := time.ParseDuration(chatTTL)
+		if err != nil || ttl < 0 {
+			return errors.New("CHAT_TTL must be a non-negative duration")
+		}
+		chatTTLValue = ttl
+	}
+
+	// REDACTION=none berarti tidak ada data pribadi yang disamarkan
+	switch redaction {
+	case "":
+		redactionRules = redact.RuleNames()
+	case "none":
+		redactionRules = nil
+	default:
+		redactionRules = strings.Split(redaction, ",")
+	}
+
+	if guardModerator == "" {
+		guardModerator = handler.MODERATOR_OPENAI
+	}
+
+	switch guardModerator {
+	case handler.MODERATOR_OPENAI, handler.MODERATOR_LOCAL, handler.MODERATOR_NONE:
+	default:
+		return errors.New("GUARD_MODERATOR must be one of openai, local, or none")
+	}
+
+	if guardInjection == "" {
+		guardInjection = "neutralize"
+	}
+
+	if shareKey != "" && len(shareKey) < share.MIN_KEY_SIZE {
+		return fmt.Errorf("SHARE_KEY must be at least %d characters", share.MIN_KEY_SIZE)
+	}
+
+	if templateDir == "" {
+		templateDir = "templates"
+	}
+
+	if questionDir == "" {
+		questionDir = "questions"
+	}
+
+	if sandboxCacheDir == "" {
+		sandboxCacheDir = "cache/go-build"
+	}
+
+	if sandboxTimeout != "" {
+		timeout, err := time.ParseDuration(sandboxTimeout)
+		if err != nil || timeout <= 0 {
+			return errors.New("SANDBOX_TIMEOUT must be a positive duration")
+		}
+		sandboxLimits.Timeout = timeout
+	}
+
+	if sandboxCPU != "" {
+		cpu, err := time.ParseDuration(sandboxCPU)
+		if err != nil || cpu < time.Second {
+			return errors.New("SANDBOX_CPU must be a duration of at least 1s")
+		}
+		sandboxLimits.CPU = cpu
+	}
+
+	// SANDBOX_MEMORY ditulis dalam megabyte
+	if sandboxMemory != "" {
+		memory, err := strconv.Atoi(sandboxMemory)
+		if err != nil || memory <= 0 {
+			return errors.New("SANDBOX_MEMORY must be a positive number of megabytes")
+		}
+		sandboxLimits.Memory = int64(memory) << 20
+	}
+
 	return nil
 }
@@ -0,0 +1,37 @@
+package model
+
+type SubmitCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// SubmitDesignRequest memakai struktur yang sama dengan Design, Edges dan SubmittedAt diabaikan
+type SubmitDesignRequest = Design
+
+type CreateShareRequest struct {
+	Label string `json:"label,omitempty"`
+	// ExpiresIn adalah masa berlaku link dalam format durasi Go, misalnya "72h"
+	ExpiresIn string `json:"expires_in,omitempty"`
+}
+
+type CreateAccountRequest struct {
+	Name string `json:"name"`
+	// Role adalah peran akun, yaitu reviewer, admin, atau service
+	Role string `json:"role"`
+}
+
+type ReviewCommentRequest struct {
+	// Turn adalah nomor jawaban yang dikomentari, sama dengan turn di scorecard
+	Turn int    `json:"turn"`
+	Text string `json:"text"`
+}
+
+type ScoreOverrideRequest struct {
+	Level         int    `json:"level"`
+	Justification string `json:"justification"`
+}
+
+// UpdateReviewRequest mengubah status review, field kosong berarti tidak diubah
+type UpdateReviewRequest struct {
+	Reviewed  *bool `json:"reviewed,omitempty"`
+	Published *bool `json:"published,omitempty"`
+}
@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 type Response struct {
 	Error   error  `json:"error,omitempty"`
 	Message string `json:"message,omitempty"`
@@ -7,18 +9,367 @@ type Response struct {
 }
 
 type StartChatResponse struct {
-	ID     string `json:"id"`
-	Secret string `json:"secret"`
+	ID       string `json:"id"`
+	Secret   string `json:"secret"`
+	Template string `json:"template"`
+	Language string `json:"language"`
+	Stage    string `json:"stage"`
 
 	Chat
 }
 
 type Chat struct {
-	Audio string `json:"audio,omitempty"`
-	Text  string `json:"text,omitempty"`
+	AudioURL string `json:"audio_url,omitempty"`
+	Text     string `json:"text,omitempty"`
+
+	// Speaker diisi dengan persona yang berbicara pada mode panel
+	Speaker *Speaker `json:"speaker,omitempty"`
+}
+
+type Speaker struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
 }
 
 type AnswerChatResponse struct {
-	Prompt Chat `json:"prompt,omitempty"`
-	Answer Chat `json:"answer,omitempty"`
+	Language string `json:"language,omitempty"`
+	Stage    string `json:"stage"`
+	Finished bool   `json:"finished"`
+	Prompt   Chat   `json:"prompt,omitempty"`
+	Answer   Chat   `json:"answer,omitempty"`
+
+	// Problem diisi ketika interviewer memberikan soal coding
+	Problem *CodingProblem `json:"problem,omitempty"`
+}
+
+type CodingProblem struct {
+	QuestionID  string `json:"question_id"`
+	Text        string `json:"text"`
+	StarterCode string `json:"starter_code,omitempty"`
+}
+
+type SubmitCodeResponse struct {
+	Stage      string         `json:"stage"`
+	Finished   bool           `json:"finished"`
+	Submission Submission     `json:"submission"`
+	Answer     Chat           `json:"answer,omitempty"`
+	Problem    *CodingProblem `json:"problem,omitempty"`
+}
+
+type Submission struct {
+	QuestionID string       `json:"question_id"`
+	Status     string       `json:"status"`
+	Passed     int          `json:"passed"`
+	Total      int          `json:"total"`
+	Tests      []TestResult `json:"tests"`
+	Output     string       `json:"output,omitempty"`
+}
+
+type TestResult struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+}
+
+type Recording struct {
+	Turn       int       `json:"turn"`
+	Transcript string    `json:"transcript"`
+	AudioURL   string    `json:"audio_url"`
+	RecordedAt time.Time `json:"recorded_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type SubmitDesignResponse struct {
+	Stage    string `json:"stage"`
+	Finished bool   `json:"finished"`
+	Design   Design `json:"design"`
+	Answer   Chat   `json:"answer,omitempty"`
+}
+
+type Design struct {
+	Components  []DesignComponent `json:"components"`
+	DataStores  []DesignDataStore `json:"data_stores"`
+	APIs        []DesignAPI       `json:"apis"`
+	Diagram     DesignDiagram     `json:"diagram"`
+	Notes       string            `json:"notes,omitempty"`
+	Edges       []DesignEdge      `json:"edges,omitempty"`
+	SubmittedAt time.Time         `json:"submitted_at,omitempty"`
+
+	// Summary adalah desain dalam bentuk teks yang sama dengan yang dikirim ke interviewer
+	Summary string `json:"summary,omitempty"`
+}
+
+type DesignComponent struct {
+	Name           string `json:"name"`
+	Responsibility string `json:"responsibility,omitempty"`
+}
+
+type DesignDataStore struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind,omitempty"`
+	Purpose string `json:"purpose,omitempty"`
+}
+
+type DesignAPI struct {
+	Method      string `json:"method,omitempty"`
+	Path        string `json:"path"`
+	Description string `json:"description,omitempty"`
+}
+
+type DesignDiagram struct {
+	Format string `json:"format,omitempty"`
+	Source string `json:"source,omitempty"`
+}
+
+type DesignEdge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Label string `json:"label,omitempty"`
+}
+
+type Scorecard struct {
+	Template      string  `json:"template"`
+	RubricVersion string  `json:"rubric_version"`
+	Overall       float64 `json:"overall"`
+	// AutomatedOverall adalah nilai keseluruhan dari AI, hanya dikirim jika Overall berubah karena override reviewer
+	AutomatedOverall *float64          `json:"automated_overall,omitempty"`
+	ScoredAnswers    int               `json:"scored_answers"`
+	Competencies     []CompetencyScore `json:"competencies"`
+	Answers          []AnswerScore     `json:"answers"`
+
+	// Behavioral berisi analisis STAR jawaban behavioral beserta saran perbaikannya
+	Behavioral []BehavioralAnswer `json:"behavioral"`
+
+	// Speech berisi ringkasan cara user berbicara di semua jawaban lisan
+	Speech *SpeechSummary `json:"speech,omitempty"`
+
+	// Design berisi desain sistem terakhir yang dikirim user di tahap system design
+	Design *Design `json:"design,omitempty"`
+
+	// Review berisi status dan komentar reviewer, hanya dikirim ke kandidat jika sudah dipublikasikan
+	Review *Review `json:"review,omitempty"`
+}
+
+type CompetencyScore struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Average  float64 `json:"average"`
+	MaxLevel int     `json:"max_level"`
+	Count    int     `json:"count"`
+
+	// Override berisi nilai dari reviewer yang menggantikan rata-rata AI di nilai keseluruhan
+	Override *ScoreOverride `json:"override,omitempty"`
+}
+
+type AnswerScore struct {
+	Turn       int     `json:"turn"`
+	Stage      string  `json:"stage"`
+	QuestionID string  `json:"question_id,omitempty"`
+	Scores     []Score `json:"scores"`
+}
+
+type Score struct {
+	Competency string `json:"competency"`
+	Level      int    `json:"level"`
+	Evidence   string `json:"evidence"`
+}
+
+type SpeechSummary struct {
+	Answers              int            `json:"answers"`
+	Words                int            `json:"words"`
+	SpeakingSeconds      float64        `json:"speaking_seconds"`
+	WordsPerMinute       float64        `json:"words_per_minute"`
+	FillerCount          int            `json:"filler_count"`
+	FillersPerMinute     float64        `json:"fillers_per_minute"`
+	Fillers              map[string]int `json:"fillers,omitempty"`
+	LongPauses           int            `json:"long_pauses"`
+	LongestPauseSeconds  float64        `json:"longest_pause_seconds"`
+	AverageAnswerSeconds float64        `json:"average_answer_seconds"`
+}
+
+type BehavioralAnswer struct {
+	Turn        int      `json:"turn"`
+	QuestionID  string   `json:"question_id,omitempty"`
+	Situation   string   `json:"situation"`
+	Task        string   `json:"task"`
+	Action      string   `json:"action"`
+	Result      string   `json:"result"`
+	Complete    bool     `json:"complete"`
+	Missing     []string `json:"missing"`
+	Suggestions []string `json:"suggestions"`
+}
+
+type IdealAnswer struct {
+	Turn       int    `json:"turn"`
+	QuestionID string `json:"question_id,omitempty"`
+	Question   string `json:"question"`
+	Answer     string `json:"answer"`
+	Ideal      string `json:"ideal"`
+
+	// Segments berisi contoh jawaban per bagian, bagian dengan missing true membahas poin yang tidak dibahas user
+	Segments    []IdealSegment `json:"segments"`
+	KeyPoints   []KeyPoint     `json:"key_points"`
+	Missing     []string       `json:"missing"`
+	GeneratedAt time.Time      `json:"generated_at"`
+}
+
+type IdealSegment struct {
+	Text     string `json:"text"`
+	KeyPoint string `json:"key_point,omitempty"`
+	Missing  bool   `json:"missing"`
+}
+
+type KeyPoint struct {
+	Text    string `json:"text"`
+	Covered bool   `json:"covered"`
+}
+
+// Transcript adalah isi export interview, dipakai langsung sebagai export JSON
+type Transcript struct {
+	ID         string              `json:"id"`
+	Template   string              `json:"template"`
+	Title      string              `json:"title"`
+	Language   string              `json:"language"`
+	Stage      string              `json:"stage"`
+	Finished   bool                `json:"finished"`
+	StartedAt  time.Time           `json:"started_at"`
+	ExportedAt time.Time           `json:"exported_at"`
+	Messages   []TranscriptMessage `json:"messages"`
+	Scorecard  Scorecard           `json:"scorecard"`
+
+	// Flags berisi jawaban yang ditandai guard, hanya dikirim ke reviewer, admin, dan service
+	Flags []AnswerFlag `json:"flags,omitempty"`
+}
+
+// AnswerFlag adalah hasil pengecekan guard yang menandai satu jawaban
+type AnswerFlag struct {
+	// Answer adalah nomor jawaban, sama dengan turn di scorecard
+	Answer int    `json:"answer"`
+	Kind   string `json:"kind"`
+	Detail string `json:"detail,omitempty"`
+	Action string `json:"action"`
+}
+
+type TranscriptMessage struct {
+	// Answer adalah nomor jawaban untuk pesan user, sama dengan turn di scorecard
+	Answer    int       `json:"answer,omitempty"`
+	Role      string    `json:"role"`
+	Speaker   *Speaker  `json:"speaker,omitempty"`
+	Stage     string    `json:"stage,omitempty"`
+	Text      string    `json:"text"`
+	AudioURL  string    `json:"audio_url,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+
+	// Comments berisi komentar reviewer untuk jawaban ini
+	Comments []ReviewComment `json:"comments,omitempty"`
+}
+
+type Share struct {
+	ID        string    `json:"id"`
+	Label     string    `json:"label,omitempty"`
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Accesses berisi akses terakhir ke link ini, dari yang paling lama
+	Accesses []ShareAccess `json:"accesses"`
+}
+
+type ShareAccess struct {
+	Resource  string    `json:"resource"`
+	IP        string    `json:"ip,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	At        time.Time `json:"at"`
+}
+
+type AdminChatList struct {
+	Chats []AdminChat `json:"chats"`
+	// NextCursor dikirim sebagai query cursor untuk mengambil halaman berikutnya, kosong berarti halaman terakhir
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type AdminChat struct {
+	ID       string `json:"id"`
+	Template string `json:"template"`
+	Language string `json:"language"`
+	Stage    string `json:"stage"`
+	Finished bool   `json:"finished"`
+	Answers  int    `json:"answers"`
+
+	// Overall kosong jika chat belum dinilai
+	Overall       *float64 `json:"overall,omitempty"`
+	ScoredAnswers int      `json:"scored_answers"`
+
+	// FlaggedAnswers adalah jumlah jawaban yang ditandai guard
+	FlaggedAnswers int `json:"flagged_answers"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TemplateStats struct {
+	Template       string  `json:"template"`
+	Title          string  `json:"title,omitempty"`
+	Chats          int     `json:"chats"`
+	Finished       int     `json:"finished"`
+	Scored         int     `json:"scored"`
+	AverageScore   float64 `json:"average_score"`
+	AverageAnswers float64 `json:"average_answers"`
+}
+
+type Account struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+	// Token hanya dikirim sekali saat akun dibuat karena yang disimpan hanya hash-nya
+	Token       string    `json:"token,omitempty"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Template struct {
+	Name      string   `json:"name"`
+	Title     string   `json:"title"`
+	Stages    []string `json:"stages"`
+	Panelists []string `json:"panelists,omitempty"`
+}
+
+type Review struct {
+	Reviewed    bool            `json:"reviewed"`
+	ReviewedBy  string          `json:"reviewed_by,omitempty"`
+	ReviewedAt  time.Time       `json:"reviewed_at,omitempty"`
+	Published   bool            `json:"published"`
+	PublishedAt time.Time       `json:"published_at,omitempty"`
+	Comments    []ReviewComment `json:"comments"`
+
+	// Events berisi jejak audit review dari yang paling lama, hanya dikirim ke reviewer
+	Events []ReviewEvent `json:"events,omitempty"`
+}
+
+type ReviewComment struct {
+	ID        string    `json:"id"`
+	Turn      int       `json:"turn"`
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ScoreOverride struct {
+	Level         int       `json:"level"`
+	Justification string    `json:"justification"`
+	Author        string    `json:"author"`
+	At            time.Time `json:"at"`
+}
+
+type ReviewEvent struct {
+	Action        string    `json:"action"`
+	Actor         string    `json:"actor"`
+	At            time.Time `json:"at"`
+	Turn          int       `json:"turn,omitempty"`
+	CommentID     string    `json:"comment_id,omitempty"`
+	Competency    string    `json:"competency,omitempty"`
+	Level         int       `json:"level,omitempty"`
+	PreviousLevel int       `json:"previous_level,omitempty"`
+	Text          string    `json:"text,omitempty"`
 }
@@ -0,0 +1,155 @@
+package redact
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Rule adalah satu jenis data pribadi yang disamarkan
+type Rule struct {
+	Name    string
+	Label   string
+	Pattern *regexp.Regexp
+}
+
+const (
+	RULE_EMAIL = "email"
+	RULE_URL   = "url"
+	RULE_NIK   = "nik"
+	RULE_PHONE = "phone"
+)
+
+// rules diurutkan dari yang paling spesifik agar NIK tidak terbaca sebagai nomor telepon
+var rules = []Rule{
+	{
+		Name:    RULE_EMAIL,
+		Label:   "email address",
+		Pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+	},
+	{
+		Name:    RULE_URL,
+		Label:   "link",
+		Pattern: regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s]+[^\s.,!?)]`),
+	},
+	{
+		Name:    RULE_NIK,
+		Label:   "national ID number",
+		Pattern: regexp.MustCompile(`\b\d{6}[\s.-]?\d{6}[\s.-]?\d{4}\b`),
+	},
+	{
+		Name:    RULE_PHONE,
+		Label:   "phone number",
+		Pattern: regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?|\b0)\d{2,4}[\s.-]?\d{3,4}[\s.-]?\d{2,5}\b|\(?\b\d{3}\)?[\s.-]\d{3}[\s.-]\d{4}\b`),
+	},
+}
+
+// placeholderPattern digunakan untuk menemukan placeholder di teks
+var placeholderPattern = regexp.MustCompile(`\[([A-Z]+)_[a-f0-9]{8}\]`)
+
+// Mapping berisi placeholder dan nilai aslinya
+type Mapping map[string]string
+
+// Redactor menyamarkan data pribadi di teks
+type Redactor struct {
+	rules  []Rule
+	labels map[string]string
+}
+
+// RuleNames digunakan untuk mendapatkan nama semua jenis data pribadi yang didukung
+func RuleNames() []string {
+	names := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		names = append(names, rule.Name)
+	}
+
+	return names
+}
+
+// New digunakan untuk membuat redactor dengan jenis data pribadi yang dipilih,
+// daftar kosong berarti tidak ada yang disamarkan
+func New(names []string) (*Redactor, error) {
+	enabled := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		enabled[name] = true
+	}
+
+	r := &Redactor{labels: make(map[string]string)}
+	for _, rule := range rules {
+		if !enabled[rule.Name] {
+			continue
+		}
+
+		r.rules = append(r.rules, rule)
+		r.labels[placeholderKind(rule.Name)] = rule.Label
+		delete(enabled, rule.Name)
+	}
+
+	for name := range enabled {
+		return nil, fmt.Errorf("unknown redaction rule: %s", name)
+	}
+
+	return r, nil
+}
+
+// Redact digunakan untuk mengganti data pribadi dengan placeholder,
+// placeholder selalu sama untuk nilai dan salt yang sama
+func (r *Redactor) Redact(text, salt string) (string, Mapping) {
+	mapping := make(Mapping)
+	if r == nil {
+		return text, mapping
+	}
+
+	for _, rule := range r.rules {
+		text = rule.Pattern.ReplaceAllStringFunc(text, func(value string) string {
+			placeholder := newPlaceholder(rule.Name, value, salt)
+			mapping[placeholder] = value
+
+			return placeholder
+		})
+	}
+
+	return text, mapping
+}
+
+// Speakable digunakan untuk mengganti placeholder dengan label yang enak didengar untuk TTS
+func (r *Redactor) Speakable(text string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		kind := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		if label, ok := r.labels[kind]; ok {
+			return "the " + label
+		}
+
+		return placeholder
+	})
+}
+
+// Restore digunakan untuk mengembalikan placeholder menjadi nilai aslinya untuk ditampilkan
+func Restore(text string, mapping Mapping) string {
+	if len(mapping) == 0 {
+		return text
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		if value, ok := mapping[placeholder]; ok {
+			return value
+		}
+
+		return placeholder
+	})
+}
+
+func newPlaceholder(name, value, salt string) string {
+	sum := sha256.Sum256([]byte(salt + "\x00" + value))
+	return fmt.Sprintf("[%s_%s]", placeholderKind(name), hex.EncodeToString(sum[:4]))
+}
+
+func placeholderKind(name string) string {
+	return strings.ToUpper(name)
+}
@@ -0,0 +1,180 @@
+package redact
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		// want berisi nilai yang harus disamarkan beserta jenis placeholdernya
+		want map[string]string
+	}{
+		{
+			name: "email",
+			text: "Mail me at budi.santoso+jobs@example.co.id tomorrow.",
+			want: map[string]string{"budi.santoso+jobs@example.co.id": "EMAIL"},
+		},
+		{
+			name: "url",
+			text: "My portfolio is at https://budi.dev/projects.",
+			want: map[string]string{"https://budi.dev/projects": "URL"},
+		},
+		{
+			name: "national ID number",
+			text: "My NIK is 3201234567890001.",
+			want: map[string]string{"3201234567890001": "NIK"},
+		},
+		{
+			name: "national ID number with separators is not a phone number",
+			text: "NIK 320123-456789-0001 ok",
+			want: map[string]string{"320123-456789-0001": "NIK"},
+		},
+		{
+			name: "international phone number",
+			text: "Call +62 812-3456-7890 after five.",
+			want: map[string]string{"+62 812-3456-7890": "PHONE"},
+		},
+		{
+			name: "local phone number",
+			text: "Call 0812 3456 7890 after five.",
+			want: map[string]string{"0812 3456 7890": "PHONE"},
+		},
+		{
+			name: "us phone number",
+			text: "Call (555) 123-4567 after five.",
+			want: map[string]string{"(555) 123-4567": "PHONE"},
+		},
+		{
+			name: "email and phone in one answer",
+			text: "budi@example.com or 081234567890",
+			want: map[string]string{"budi@example.com": "EMAIL", "081234567890": "PHONE"},
+		},
+		{
+			name: "numbers that are not personal data",
+			text: "We handled 120000 requests per second with p99 under 15 ms in 2023.",
+		},
+	}
+
+	redactor, err := New(RuleNames())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redacted, mapping := redactor.Redact(tt.text, "chat")
+
+			if len(mapping) != len(tt.want) {
+				t.Errorf("Redact() mapping = %v, want %d values", mapping, len(tt.want))
+			}
+			for value, kind := range tt.want {
+				if strings.Contains(redacted, value) {
+					t.Errorf("Redact() = %q, still contains %q", redacted, value)
+				}
+
+				placeholder := newPlaceholder(strings.ToLower(kind), value, "chat")
+				if mapping[placeholder] != value {
+					t.Errorf("mapping[%s] = %q, want %q", placeholder, mapping[placeholder], value)
+				}
+			}
+			if len(tt.want) == 0 && redacted != tt.text {
+				t.Errorf("Redact() = %q, want the text unchanged", redacted)
+			}
+
+			if restored := Restore(redacted, mapping); restored != tt.text {
+				t.Errorf("Restore() = %q, want %q", restored, tt.text)
+			}
+		})
+	}
+}
+
+func TestRedactPlaceholder(t *testing.T) {
+	redactor, err := New([]string{RULE_EMAIL})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	first, _ := redactor.Redact("budi@example.com", "chat-1")
+	again, _ := redactor.Redact("contact: budi@example.com", "chat-1")
+	other, _ := redactor.Redact("budi@example.com", "chat-2")
+
+	// nilai yang sama di chat yang sama selalu menjadi placeholder yang sama
+	if !strings.HasSuffix(again, first) {
+		t.Errorf("placeholder changed within a chat: %q and %q", first, again)
+	}
+	// placeholder chat lain tidak bisa dipakai untuk menebak nilai yang sama
+	if first == other {
+		t.Errorf("placeholder %q is shared between chats", first)
+	}
+	if !placeholderPattern.MatchString(first) {
+		t.Errorf("placeholder %q does not match the placeholder pattern", first)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	mapping := Mapping{"[EMAIL_1a2b3c4d]": "budi@example.com"}
+
+	tests := []struct {
+		name    string
+		text    string
+		mapping Mapping
+		want    string
+	}{
+		{name: "known placeholder", text: "mail [EMAIL_1a2b3c4d]", mapping: mapping, want: "mail budi@example.com"},
+		{name: "unknown placeholder is kept", text: "mail [EMAIL_ffffffff]", mapping: mapping, want: "mail [EMAIL_ffffffff]"},
+		{name: "empty mapping", text: "mail [EMAIL_1a2b3c4d]", want: "mail [EMAIL_1a2b3c4d]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Restore(tt.text, tt.mapping); got != tt.want {
+				t.Errorf("Restore() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{name: "only selected rules are applied", rules: []string{" Email "}, text: "0812 3456 7890", want: "0812 3456 7890"},
+		{name: "no rules", text: "budi@example.com", want: "budi@example.com"},
+		{name: "unknown rule", rules: []string{"email", "passport"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redactor, err := New(tt.rules)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got, _ := redactor.Redact(tt.text, "chat"); got != tt.want {
+				t.Errorf("Redact() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpeakable(t *testing.T) {
+	redactor, err := New(RuleNames())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	redacted, _ := redactor.Redact("Send it to budi@example.com.", "chat")
+	if got, want := redactor.Speakable(redacted), "Send it to the email address."; got != want {
+		t.Errorf("Speakable() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,73 @@
+package redact
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// maxPendingSize adalah ukuran maksimal teks tanpa baris baru yang ditahan sebelum tetap diteruskan
+const maxPendingSize = 64 << 10
+
+// Writer menyamarkan data pribadi sebelum diteruskan ke writer lain,
+// dipakai sebagai output logger. Teks diteruskan per baris agar data pribadi yang terpotong
+// di dua pemanggilan Write tetap dikenali
+type Writer struct {
+	out      io.Writer
+	redactor *Redactor
+
+	mu      sync.Mutex
+	pending []byte
+}
+
+// NewWriter digunakan untuk membuat writer yang menyamarkan data pribadi
+func NewWriter(out io.Writer, redactor *Redactor) *Writer {
+	return &Writer{
+		out:      out,
+		redactor: redactor,
+	}
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.pending = append(w.pending, p...)
+
+	// tahan sisa teks setelah baris baru terakhir sampai barisnya lengkap
+	end := bytes.LastIndexByte(w.pending, '\n') + 1
+	if end == 0 && len(w.pending) < maxPendingSize {
+		return len(p), nil
+	}
+	if end == 0 {
+		end = len(w.pending)
+	}
+
+	if err := w.flush(end); err != nil {
+		return 0, err
+	}
+
+	// kembalikan panjang asli agar logger tidak menganggap penulisan gagal
+	return len(p), nil
+}
+
+// Flush digunakan untuk meneruskan teks yang masih ditahan karena belum diakhiri baris baru
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.flush(len(w.pending))
+}
+
+// flush digunakan untuk menyamarkan dan meneruskan n byte pertama dari teks yang ditahan
+func (w *Writer) flush(n int) error {
+	if n == 0 {
+		return nil
+	}
+
+	redacted, _ := w.redactor.Redact(string(w.pending[:n]), "log")
+	w.pending = append(w.pending[:0], w.pending[n:]...)
+
+	_, err := io.WriteString(w.out, redacted)
+	return err
+}
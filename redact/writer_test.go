@@ -0,0 +1,86 @@
+package redact
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		// flush berarti Flush dipanggil setelah semua Write
+		flush bool
+		want  string
+	}{
+		{
+			name:   "one line",
+			writes: []string{"login budi@example.com\n"},
+			want:   "login " + newPlaceholder(RULE_EMAIL, "budi@example.com", "log") + "\n",
+		},
+		{
+			name:   "email split across two writes",
+			writes: []string{"login budi@exam", "ple.com\n"},
+			want:   "login " + newPlaceholder(RULE_EMAIL, "budi@example.com", "log") + "\n",
+		},
+		{
+			name:   "phone split across two writes",
+			writes: []string{"call 0812 34", "56 7890\nnext"},
+			want:   "call " + newPlaceholder(RULE_PHONE, "0812 3456 7890", "log") + "\n",
+		},
+		{
+			name:   "unfinished line is written on flush",
+			writes: []string{"call 0812 34", "56 7890"},
+			flush:  true,
+			want:   "call " + newPlaceholder(RULE_PHONE, "0812 3456 7890", "log"),
+		},
+	}
+
+	redactor, err := New(RuleNames())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			w := NewWriter(&out, redactor)
+
+			for _, p := range tt.writes {
+				n, err := w.Write([]byte(p))
+				if err != nil || n != len(p) {
+					t.Fatalf("Write() = %d, %v, want %d, nil", n, err, len(p))
+				}
+			}
+			if tt.flush {
+				if err := w.Flush(); err != nil {
+					t.Fatalf("Flush() error = %v", err)
+				}
+			}
+
+			if out.String() != tt.want {
+				t.Errorf("output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriterLongLine(t *testing.T) {
+	redactor, err := New(RuleNames())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	w := NewWriter(&out, redactor)
+
+	// teks tanpa baris baru tidak ditahan tanpa batas
+	line := strings.Repeat("a", maxPendingSize)
+	if _, err := w.Write([]byte(line)); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if out.Len() != len(line) {
+		t.Errorf("output length = %d, want %d", out.Len(), len(line))
+	}
+}
@@ -0,0 +1,147 @@
+package sandbox
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// deniedImports adalah package yang bisa dipakai kode user untuk keluar dari proses tanpa melaporkan
+// hasil test, menjalankan program lain, atau membaca file test tersembunyi saat kompilasi
+var deniedImports = []string{"embed", "os/exec", "os/signal", "plugin", "syscall", "unsafe"}
+
+// checkSource digunakan untuk menolak kode user yang bisa berjalan sebelum test dimulai atau
+// memalsukan hasil test. Hasil test dibaca dari output binary test, jadi kode user tidak boleh
+// berjalan sebelum framework test atau menghentikan proses dengan status sukses
+func checkSource(source string) error {
+	file, err := parser.ParseFile(token.NewFileSet(), sourceFile, source, parser.SkipObjectResolution)
+	if err != nil {
+		// error sintaks dilaporkan oleh kompilasi beserta posisinya
+		return nil
+	}
+
+	osName := ""
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		if slices.Contains(deniedImports, importPath) || strings.HasPrefix(importPath, "syscall/") {
+			return fmt.Errorf("import %q is not allowed", importPath)
+		}
+
+		if importPath == "os" {
+			osName = "os"
+			if spec.Name != nil {
+				osName = spec.Name.Name
+			}
+			if osName == "." {
+				return fmt.Errorf("dot import of %q is not allowed", importPath)
+			}
+		}
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil && (decl.Name.Name == "init" || decl.Name.Name == "TestMain") {
+				return fmt.Errorf("func %s is not allowed", decl.Name.Name)
+			}
+		case *ast.GenDecl:
+			if decl.Tok != token.VAR {
+				continue
+			}
+
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.ValueSpec)
+				for _, value := range spec.Values {
+					if containsCall(value) {
+						return fmt.Errorf("package-level variable %s must not call functions, initialize it inside a function instead", spec.Names[0].Name)
+					}
+				}
+			}
+		}
+	}
+
+	// os.Exit(0) di luar test membuat proses berhenti dengan sukses sebelum hasil test dilaporkan
+	if osName != "" && osName != "_" {
+		exit := false
+		ast.Inspect(file, func(n ast.Node) bool {
+			if selector, ok := n.(*ast.SelectorExpr); ok && selector.Sel.Name == "Exit" {
+				if ident, ok := selector.X.(*ast.Ident); ok && ident.Name == osName {
+					exit = true
+				}
+			}
+
+			return !exit
+		})
+		if exit {
+			return fmt.Errorf("%s.Exit is not allowed", osName)
+		}
+	}
+
+	return nil
+}
+
+// containsCall digunakan untuk mengecek apakah ekspresi memanggil fungsi di luar function literal
+func containsCall(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.FuncLit:
+			// isi function literal baru berjalan ketika dipanggil
+			return false
+		case *ast.CallExpr:
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// testNames digunakan untuk mendapatkan nama semua fungsi test di file test tersembunyi
+func testNames(tests string) ([]string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), testFile, tests, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hidden tests: %w", err)
+	}
+
+	var names []string
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || !isTestName(fn.Name.Name) {
+			continue
+		}
+
+		names = append(names, fn.Name.Name)
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("hidden tests have no test functions")
+	}
+
+	return names, nil
+}
+
+// isTestName digunakan untuk mengecek nama fungsi test dengan aturan yang sama seperti go test
+func isTestName(name string) bool {
+	suffix, ok := strings.CutPrefix(name, "Test")
+	if !ok || name == "TestMain" {
+		return false
+	}
+	if suffix == "" {
+		return true
+	}
+
+	r, _ := utf8.DecodeRuneInString(suffix)
+	return !unicode.IsLower(r)
+}
@@ -0,0 +1,102 @@
+package sandbox
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCheckSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		rejected bool
+	}{
+		{
+			name:   "plain function",
+			source: "package solution\n\nfunc Add(a, b int) int { return a + b }\n",
+		},
+		{
+			name:   "package-level variable without call",
+			source: "package solution\n\nvar cache = map[int]int{}\n\nvar double = func(n int) int { return n * 2 }\n",
+		},
+		{
+			name:   "syntax error is left to the build",
+			source: "package solution\n\nfunc Add(",
+		},
+		{
+			name:     "init",
+			source:   "package solution\n\nfunc init() {}\n",
+			rejected: true,
+		},
+		{
+			name:     "TestMain",
+			source:   "package solution\n\nimport \"testing\"\n\nfunc TestMain(m *testing.M) {}\n",
+			rejected: true,
+		},
+		{
+			name:     "package-level variable with call",
+			source:   "package solution\n\nimport \"fmt\"\n\nvar _ = fmt.Sprint(\"x\")\n",
+			rejected: true,
+		},
+		{
+			name:     "os.Exit",
+			source:   "package solution\n\nimport \"os\"\n\nfunc Add(a, b int) int { os.Exit(0); return 0 }\n",
+			rejected: true,
+		},
+		{
+			name:     "renamed os.Exit",
+			source:   "package solution\n\nimport sys \"os\"\n\nvar exit = sys.Exit\n",
+			rejected: true,
+		},
+		{
+			name:     "dot import of os",
+			source:   "package solution\n\nimport . \"os\"\n",
+			rejected: true,
+		},
+		{
+			name:     "syscall",
+			source:   "package solution\n\nimport \"syscall\"\n\nfunc Add() { syscall.Exit(0) }\n",
+			rejected: true,
+		},
+		{
+			name:     "embed",
+			source:   "package solution\n\nimport _ \"embed\"\n",
+			rejected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSource(tt.source)
+			if (err != nil) != tt.rejected {
+				t.Fatalf("checkSource() error = %v, want rejected %v", err, tt.rejected)
+			}
+		})
+	}
+}
+
+func TestTestNames(t *testing.T) {
+	tests := `package solution
+
+import "testing"
+
+func TestAdd(t *testing.T) {}
+
+func Test(t *testing.T) {}
+
+func Testify(t *testing.T) {}
+
+func TestMain(m *testing.M) {}
+
+func helper(t *testing.T) {}
+`
+
+	names, err := testNames(tests)
+	if err != nil {
+		t.Fatalf("testNames() error = %v", err)
+	}
+
+	if want := []string{"TestAdd", "Test"}; !slices.Equal(names, want) {
+		t.Fatalf("testNames() = %v, want %v", names, want)
+	}
+}
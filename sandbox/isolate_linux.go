@@ -0,0 +1,272 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"syscall"
+)
+
+const (
+	// initName adalah argv[0] yang menandakan binary server dijalankan ulang sebagai proses awal sandbox
+	initName = "sandbox-init"
+	// rootBinary adalah lokasi binary test di root sandbox
+	rootBinary = "/solution.test"
+	// maxProcesses adalah batas thread yang boleh dibuat binary test
+	maxProcesses = 64
+	// signalExit adalah dasar exit code proses awal ketika binary test dihentikan oleh sinyal
+	signalExit = 128
+
+	// rlimitNproc tidak tersedia di package syscall
+	rlimitNproc = 0x6
+	// prSetNoNewPrivs tidak tersedia di package syscall
+	prSetNoNewPrivs = 38
+)
+
+// init menjalankan proses awal sandbox sebelum kode server lain berjalan
+// ketika binary ini dijalankan ulang oleh runIsolated
+func init() {
+	if len(os.Args) > 1 && os.Args[0] == initName {
+		os.Exit(sandboxInit(os.Args[1], os.Args[2:]))
+	}
+}
+
+// runIsolated digunakan untuk menjalankan binary test di namespace baru tanpa jaringan,
+// tanpa akses ke proses lain, dan dengan root berisi binary test saja.
+// Error bertipe *exec.ExitError berarti test gagal atau dihentikan, error lain berarti sandbox gagal dibuat
+func runIsolated(ctx context.Context, limits Limits, binary string, args []string, output io.Writer) error {
+	setup, setupWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer setup.Close()
+
+	// binary server dijalankan ulang agar tidak bergantung pada tool di luar proses
+	cmd := exec.CommandContext(ctx, "/proc/self/exe", binary)
+	cmd.Args[0] = initName
+	cmd.Args = append(cmd.Args, args...)
+	cmd.Dir = filepath.Dir(binary)
+	cmd.Env = []string{
+		"SANDBOX_CPU=" + strconv.Itoa(max(int(limits.CPU.Seconds()), 1)),
+		"SANDBOX_MEMORY=" + strconv.FormatInt(limits.Memory, 10),
+		"GOMAXPROCS=1",
+	}
+	cmd.Stdout = output
+	cmd.Stderr = output
+	cmd.ExtraFiles = []*os.File{setupWriter}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWUSER | syscall.CLONE_NEWNET | syscall.CLONE_NEWPID | syscall.CLONE_NEWNS | syscall.CLONE_NEWIPC | syscall.CLONE_NEWUTS,
+		UidMappings: []syscall.SysProcIDMap{
+			{ContainerID: 0, HostID: os.Getuid(), Size: 1},
+		},
+		GidMappings: []syscall.SysProcIDMap{
+			{ContainerID: 0, HostID: os.Getgid(), Size: 1},
+		},
+		Pdeathsig: syscall.SIGKILL,
+	}
+
+	err = cmd.Start()
+	setupWriter.Close()
+	if err != nil {
+		return err
+	}
+
+	// proses awal menutup pipe sebelum menjalankan binary test, isinya hanya pesan error persiapan
+	message, _ := io.ReadAll(setup)
+	err = cmd.Wait()
+	if len(message) > 0 {
+		return fmt.Errorf("failed to prepare sandbox: %s", message)
+	}
+
+	return err
+}
+
+// sandboxInit digunakan sebagai proses awal di namespace sandbox. Proses ini memindahkan root
+// ke tmpfs yang hanya berisi binary test, memasang batas sumber daya, lalu menjalankan binary
+// sebagai uid yang tidak dipetakan di user namespace baru sehingga tidak punya hak apa pun
+func sandboxInit(binary string, args []string) int {
+	// pipe persiapan tidak boleh diwariskan ke binary test
+	syscall.CloseOnExec(3)
+	setup := os.NewFile(3, "setup")
+	fail := func(err error) int {
+		fmt.Fprint(setup, err)
+		return 1
+	}
+
+	if err := enterRoot(binary); err != nil {
+		return fail(err)
+	}
+
+	if err := setLimits(); err != nil {
+		return fail(err)
+	}
+
+	// no_new_privs berlaku per thread dan diwariskan ke proses yang dibuat dari thread ini
+	runtime.LockOSThread()
+	if _, _, errno := syscall.RawSyscall6(syscall.SYS_PRCTL, prSetNoNewPrivs, 1, 0, 0, 0, 0); errno != 0 {
+		return fail(fmt.Errorf("failed to set no_new_privs: %w", errno))
+	}
+
+	cmd := exec.Command(rootBinary, args...)
+	cmd.Dir = "/tmp"
+	cmd.Env = []string{
+		"HOME=/tmp",
+		"TMPDIR=/tmp",
+		"GOMAXPROCS=1",
+		"GOMEMLIMIT=" + os.Getenv("SANDBOX_MEMORY"),
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		// tanpa pemetaan uid, binary test berjalan sebagai overflow uid tanpa capability
+		Cloneflags: syscall.CLONE_NEWUSER,
+		Pdeathsig:  syscall.SIGKILL,
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fail(err)
+	}
+	setup.Close()
+
+	err := cmd.Wait()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		status := exitErr.Sys().(syscall.WaitStatus)
+		if status.Signaled() {
+			return signalExit + int(status.Signal())
+		}
+
+		return status.ExitStatus()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+// enterRoot digunakan untuk memindahkan root proses ke tmpfs baru yang berisi binary test,
+// /proc milik pid namespace sandbox, dan /tmp yang bisa ditulis
+func enterRoot(binary string) error {
+	// perubahan mount tidak boleh terlihat di mount namespace server
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("failed to make mounts private: %w", err)
+	}
+
+	root, err := os.MkdirTemp(filepath.Dir(binary), "root-")
+	if err != nil {
+		return err
+	}
+
+	if err := syscall.Mount("tmpfs", root, "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, "size=64m,mode=0755"); err != nil {
+		return fmt.Errorf("failed to mount root: %w", err)
+	}
+
+	if err := copyFile(binary, filepath.Join(root, rootBinary), 0o555); err != nil {
+		return err
+	}
+
+	mounts := []struct {
+		target string
+		fstype string
+		mode   os.FileMode
+		data   string
+	}{
+		{target: "proc", fstype: "proc", mode: 0o555},
+		{target: "tmp", fstype: "tmpfs", mode: 0o777, data: "size=16m,mode=1777"},
+	}
+	for _, m := range mounts {
+		target := filepath.Join(root, m.target)
+		if err := os.Mkdir(target, m.mode); err != nil {
+			return err
+		}
+
+		if err := syscall.Mount(m.fstype, target, m.fstype, syscall.MS_NOSUID|syscall.MS_NODEV|syscall.MS_NOEXEC, m.data); err != nil {
+			return fmt.Errorf("failed to mount /%s: %w", m.target, err)
+		}
+	}
+
+	if err := os.Chdir(root); err != nil {
+		return err
+	}
+
+	// root lama ditumpuk di bawah root baru lalu dilepas sehingga filesystem server tidak bisa diakses
+	if err := syscall.PivotRoot(".", "."); err != nil {
+		return fmt.Errorf("failed to pivot root: %w", err)
+	}
+
+	if err := syscall.Unmount(".", syscall.MNT_DETACH); err != nil {
+		return fmt.Errorf("failed to detach old root: %w", err)
+	}
+
+	if err := os.Chdir("/"); err != nil {
+		return err
+	}
+
+	if err := syscall.Mount("", "/", "", syscall.MS_REMOUNT|syscall.MS_BIND|syscall.MS_RDONLY|syscall.MS_NOSUID|syscall.MS_NODEV, ""); err != nil {
+		return fmt.Errorf("failed to make root read-only: %w", err)
+	}
+
+	return nil
+}
+
+// setLimits digunakan untuk memasang batas CPU, memori, jumlah proses, dan core dump
+// yang diwariskan ke binary test
+func setLimits() error {
+	cpu, err := strconv.ParseUint(os.Getenv("SANDBOX_CPU"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid cpu limit: %w", err)
+	}
+
+	memory, err := strconv.ParseUint(os.Getenv("SANDBOX_MEMORY"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid memory limit: %w", err)
+	}
+
+	limits := []struct {
+		resource int
+		value    uint64
+	}{
+		{resource: syscall.RLIMIT_CPU, value: cpu},
+		{resource: syscall.RLIMIT_DATA, value: memory},
+		{resource: rlimitNproc, value: maxProcesses},
+		{resource: syscall.RLIMIT_CORE, value: 0},
+	}
+	for _, l := range limits {
+		if err := syscall.Setrlimit(l.resource, &syscall.Rlimit{Cur: l.value, Max: l.value}); err != nil {
+			return fmt.Errorf("failed to set resource limit %d: %w", l.resource, err)
+		}
+	}
+
+	return nil
+}
+
+// exceededCPU digunakan untuk mengecek apakah test dihentikan karena batas CPU habis
+// atau karena batas waktu total
+func exceededCPU(err error) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok {
+		return false
+	}
+
+	if status.Signaled() {
+		return status.Signal() == syscall.SIGXCPU || status.Signal() == syscall.SIGKILL
+	}
+
+	code := status.ExitStatus()
+	return code == signalExit+int(syscall.SIGXCPU) || code == signalExit+int(syscall.SIGKILL)
+}
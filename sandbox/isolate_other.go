@@ -0,0 +1,18 @@
+//go:build !linux
+
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// runIsolated hanya tersedia di Linux karena membutuhkan namespace
+func runIsolated(ctx context.Context, limits Limits, binary string, args []string, output io.Writer) error {
+	return errors.New("sandbox is only supported on linux")
+}
+
+func exceededCPU(err error) bool {
+	return false
+}
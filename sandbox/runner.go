@@ -0,0 +1,423 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type Status string
+
+const (
+	STATUS_PASSED       Status = "passed"
+	STATUS_FAILED       Status = "failed"
+	STATUS_BUILD_FAILED Status = "build-failed"
+	STATUS_TIMED_OUT    Status = "timed-out"
+	STATUS_REJECTED     Status = "rejected"
+)
+
+// Limits berisi batas sumber daya saat menjalankan kode user
+type Limits struct {
+	// Timeout adalah batas waktu total untuk kompilasi dan test
+	Timeout time.Duration
+	// CPU adalah batas waktu CPU untuk menjalankan test
+	CPU time.Duration
+	// Memory adalah batas memori data untuk menjalankan test dalam byte
+	Memory int64
+}
+
+// DefaultLimits dipakai jika batas tidak diatur
+var DefaultLimits = Limits{
+	Timeout: 30 * time.Second,
+	CPU:     10 * time.Second,
+	Memory:  512 << 20,
+}
+
+const (
+	// maxOutput adalah panjang maksimal output test yang disimpan
+	maxOutput = 8 << 10
+	// warmTimeout adalah batas waktu mengisi cache build standard library saat runner dibuat
+	warmTimeout = 5 * time.Minute
+
+	goMod      = "module solution\n\ngo 1.22\n"
+	sourceFile = "solution.go"
+	testFile   = "solution_test.go"
+	testBinary = "solution.test"
+)
+
+// warmSource mengimpor package standard library yang umum dipakai soal coding
+// agar kompilasi kode user tidak perlu mengompilasi ulang package tersebut
+const warmSource = `package solution
+
+import (
+	_ "bufio"
+	_ "bytes"
+	_ "container/heap"
+	_ "container/list"
+	_ "errors"
+	_ "fmt"
+	_ "maps"
+	_ "math"
+	_ "math/bits"
+	_ "regexp"
+	_ "slices"
+	_ "sort"
+	_ "strconv"
+	_ "strings"
+	_ "sync"
+	_ "time"
+	_ "unicode"
+	_ "unicode/utf8"
+)
+`
+
+// warmTests dijalankan di sandbox saat runner dibuat untuk memastikan isolasi bisa dipakai
+const warmTests = `package solution
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSandbox(t *testing.T) {
+	if !reflect.DeepEqual([]int{1}, []int{1}) {
+		t.Fatal("unexpected result")
+	}
+}
+`
+
+// TestResult berisi hasil satu fungsi test
+type TestResult struct {
+	Name   string
+	Passed bool
+}
+
+// Result berisi hasil menjalankan kode user terhadap test
+type Result struct {
+	Status   Status
+	Tests    []TestResult
+	Output   string
+	Duration time.Duration
+}
+
+// Runner menjalankan kode Go user terhadap test tersembunyi di subprocess terisolasi
+type Runner struct {
+	goBin    string
+	cacheDir string
+	limits   Limits
+}
+
+// NewRunner digunakan untuk membuat runner. cacheDir diisi dengan hasil kompilasi standard library
+// lalu disalin untuk setiap run sehingga kompilasi kode user tidak bisa mengubahnya.
+// Runner juga menjalankan test contoh di sandbox, error berarti kode user tidak bisa dijalankan di server ini
+func NewRunner(goBin, cacheDir string, limits Limits) (*Runner, error) {
+	if goBin == "" {
+		goBin = "go"
+	}
+
+	path, err := exec.LookPath(goBin)
+	if err != nil {
+		return nil, fmt.Errorf("go toolchain not found: %w", err)
+	}
+
+	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
+		return nil, err
+	}
+
+	cacheDir, err = filepath.Abs(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Runner{
+		goBin:    path,
+		cacheDir: cacheDir,
+		limits:   limits,
+	}
+
+	if err := r.warm(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// warm digunakan untuk mengisi cache build bersama dan mengecek bahwa sandbox bisa dibuat
+func (r *Runner) warm() error {
+	result, err := r.run(warmSource, warmTests, []string{"TestSandbox"}, r.cacheDir, max(warmTimeout, r.limits.Timeout))
+	if err != nil {
+		return fmt.Errorf("failed to prepare sandbox: %w", err)
+	}
+
+	if result.Status != STATUS_PASSED {
+		return fmt.Errorf("sandbox self-test %s: %s", result.Status, result.Output)
+	}
+
+	return nil
+}
+
+// Run digunakan untuk mengompilasi source user bersama test lalu menjalankannya,
+// source dan test harus berada di package yang sama. Kode user yang bisa berjalan sebelum
+// test dimulai atau menghentikan proses sendiri ditolak dengan status STATUS_REJECTED
+func (r *Runner) Run(source, tests string) (Result, error) {
+	expected, err := testNames(tests)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if err := checkSource(source); err != nil {
+		return Result{Status: STATUS_REJECTED, Output: err.Error()}, nil
+	}
+
+	return r.run(source, tests, expected, "", r.limits.Timeout)
+}
+
+// run digunakan untuk mengompilasi dan menjalankan test. Jika cacheDir kosong,
+// kompilasi memakai salinan cache bersama yang dihapus setelah selesai
+func (r *Runner) run(source, tests string, expected []string, cacheDir string, timeout time.Duration) (Result, error) {
+	dir, err := os.MkdirTemp("", "sandbox-*")
+	if err != nil {
+		return Result{}, err
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"go.mod":   goMod,
+		sourceFile: source,
+		testFile:   tests,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			return Result{}, err
+		}
+	}
+
+	if cacheDir == "" {
+		cacheDir = filepath.Join(dir, "gocache")
+		if err := copyDir(r.cacheDir, cacheDir); err != nil {
+			return Result{}, fmt.Errorf("failed to copy build cache: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	// kompilasi tidak menjalankan kode user, dependensi dari luar stdlib tidak diizinkan
+	build := exec.CommandContext(ctx, r.goBin, "test", "-c", "-vet=off", "-o", testBinary)
+	build.Dir = dir
+	build.Env = r.env(dir, cacheDir)
+
+	output, err := build.CombinedOutput()
+	if ctx.Err() != nil {
+		return Result{Status: STATUS_TIMED_OUT, Duration: time.Since(start)}, nil
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return Result{
+			Status:   STATUS_BUILD_FAILED,
+			Output:   truncate(strings.ReplaceAll(string(output), dir+string(filepath.Separator), "")),
+			Duration: time.Since(start),
+		}, nil
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	// test tersembunyi sudah ada di binary, source tidak perlu ada saat kode user berjalan
+	if err := os.Remove(filepath.Join(dir, testFile)); err != nil {
+		return Result{}, err
+	}
+
+	// output test memakai framing test2json agar baris yang dicetak kode user tidak terbaca sebagai hasil test
+	var out bytes.Buffer
+	err = runIsolated(ctx, r.limits, filepath.Join(dir, testBinary), []string{"-test.v=test2json", "-test.count=1"}, &out)
+	duration := time.Since(start)
+
+	timedOut := ctx.Err() != nil || exceededCPU(err)
+	if err != nil && !timedOut && !errors.As(err, &exitErr) {
+		return Result{}, err
+	}
+
+	events, err := r.events(out.Bytes(), dir, cacheDir)
+	if err != nil {
+		return Result{}, err
+	}
+
+	results, passed := verdict(expected, events)
+
+	result := Result{
+		Status:   STATUS_PASSED,
+		Tests:    results,
+		Output:   truncate(eventOutput(events)),
+		Duration: duration,
+	}
+
+	switch {
+	case timedOut:
+		result.Status = STATUS_TIMED_OUT
+	case exitErr != nil || !passed:
+		// semua test tersembunyi harus lulus dan proses harus selesai dengan sukses
+		result.Status = STATUS_FAILED
+	}
+
+	return result, nil
+}
+
+// env digunakan untuk membuat environment perintah go yang tidak bisa mengunduh dependensi
+func (r *Runner) env(dir, cacheDir string) []string {
+	return []string{
+		"HOME=" + dir,
+		"PATH=" + filepath.Dir(r.goBin),
+		"GOCACHE=" + cacheDir,
+		"GOPATH=" + filepath.Join(dir, "gopath"),
+		"GOPROXY=off",
+		"GOFLAGS=-mod=mod",
+		"GOTOOLCHAIN=local",
+		"CGO_ENABLED=0",
+	}
+}
+
+// event adalah satu baris output go tool test2json
+type event struct {
+	Action string
+	Test   string
+	Output string
+}
+
+// events digunakan untuk mengubah output binary test menjadi event test2json
+func (r *Runner) events(output []byte, dir, cacheDir string) ([]event, error) {
+	convert := exec.Command(r.goBin, "tool", "test2json", "-p", "solution")
+	convert.Dir = dir
+	convert.Env = r.env(dir, cacheDir)
+	convert.Stdin = bytes.NewReader(output)
+
+	var stderr bytes.Buffer
+	convert.Stderr = &stderr
+
+	converted, err := convert.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert test output: %w: %s", err, stderr.Bytes())
+	}
+
+	var events []event
+	decoder := json.NewDecoder(bytes.NewReader(converted))
+	for {
+		var e event
+		err := decoder.Decode(&e)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode test event: %w", err)
+		}
+
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+// verdict digunakan untuk menentukan hasil setiap test tersembunyi. Test dianggap lulus hanya jika
+// ada event pass dan tidak ada event fail untuk test tersebut, test yang tidak berjalan dianggap gagal
+func verdict(expected []string, events []event) ([]TestResult, bool) {
+	passed := map[string]bool{}
+	failed := map[string]bool{}
+	for _, e := range events {
+		switch e.Action {
+		case "pass":
+			passed[e.Test] = true
+		case "fail":
+			failed[e.Test] = true
+		}
+	}
+
+	allPassed := true
+	tests := make([]TestResult, 0, len(expected))
+	for _, name := range expected {
+		ok := passed[name] && !failed[name]
+		allPassed = allPassed && ok
+
+		tests = append(tests, TestResult{Name: name, Passed: ok})
+	}
+
+	return tests, allPassed
+}
+
+// eventOutput digunakan untuk menggabungkan output dari semua event
+func eventOutput(events []event) string {
+	var output strings.Builder
+	for _, e := range events {
+		output.WriteString(e.Output)
+	}
+
+	return output.String()
+}
+
+// copyDir digunakan untuk menyalin isi direktori secara rekursif ke direktori baru
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o700)
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		// cache juga berisi binary tool seperti test2json yang harus tetap bisa dijalankan
+		return copyFile(path, target, info.Mode().Perm())
+	})
+}
+
+// copyFile digunakan untuk menyalin file dengan mode tertentu
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// truncate digunakan untuk membatasi panjang output yang disimpan
+func truncate(output string) string {
+	if len(output) <= maxOutput {
+		return output
+	}
+
+	return output[:maxOutput] + "\n... output truncated"
+}
@@ -0,0 +1,102 @@
+package share
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MIN_KEY_SIZE adalah panjang minimal kunci untuk menandatangani token
+const MIN_KEY_SIZE = 32
+
+var (
+	ErrInvalidToken = errors.New("invalid share token")
+	ErrExpiredToken = errors.New("share token has expired")
+)
+
+// Claims adalah isi token link share
+type Claims struct {
+	ChatID    string
+	ShareID   string
+	ExpiresAt time.Time
+}
+
+// Signer digunakan untuk membuat dan memeriksa token link share dengan HMAC-SHA256
+type Signer struct {
+	key []byte
+}
+
+// NewSigner digunakan untuk membuat signer dari kunci rahasia server
+func NewSigner(key []byte) (*Signer, error) {
+	if len(key) < MIN_KEY_SIZE {
+		return nil, fmt.Errorf("share key must be at least %d bytes", MIN_KEY_SIZE)
+	}
+
+	return &Signer{key: key}, nil
+}
+
+// Derive digunakan untuk membuat signer dengan kunci turunan untuk keperluan lain,
+// token dari signer turunan tidak berlaku di signer asal dan sebaliknya
+func (s *Signer) Derive(purpose string) *Signer {
+	return &Signer{key: s.signature(purpose)}
+}
+
+// Sign digunakan untuk membuat token dengan format payload.signature dalam base64 URL
+func (s *Signer) Sign(claims Claims) string {
+	payload := strings.Join([]string{claims.ChatID, claims.ShareID, strconv.FormatInt(claims.ExpiresAt.Unix(), 10)}, ":")
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.signature(encoded))
+}
+
+// Verify digunakan untuk memeriksa tanda tangan dan masa berlaku token.
+// Token yang valid belum tentu aktif, link yang dicabut dicek dari data chat
+func (s *Signer) Verify(token string, now time.Time) (Claims, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return Claims{}, ErrInvalidToken
+	}
+
+	expected, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, s.signature(encoded)) {
+		return Claims{}, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	parts := strings.Split(string(payload), ":")
+	if len(parts) != 3 {
+		return Claims{}, ErrInvalidToken
+	}
+
+	expiresAt, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	claims := Claims{
+		ChatID:    parts[0],
+		ShareID:   parts[1],
+		ExpiresAt: time.Unix(expiresAt, 0),
+	}
+	if !now.Before(claims.ExpiresAt) {
+		return claims, ErrExpiredToken
+	}
+
+	return claims, nil
+}
+
+func (s *Signer) signature(payload string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,100 @@
+package share
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestSigner digunakan untuk membuat signer dengan kunci tetap
+func newTestSigner(t *testing.T, key string) *Signer {
+	t.Helper()
+
+	signer, err := NewSigner([]byte(key))
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+
+	return signer
+}
+
+func TestNewSigner(t *testing.T) {
+	if _, err := NewSigner([]byte(strings.Repeat("k", MIN_KEY_SIZE-1))); err == nil {
+		t.Error("NewSigner() with a short key error = nil, want error")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	signer := newTestSigner(t, "0123456789abcdef0123456789abcdef")
+	other := newTestSigner(t, "fedcba9876543210fedcba9876543210")
+	audio := signer.Derive("audio")
+
+	claims := Claims{ChatID: "chat-1", ShareID: "share-1", ExpiresAt: now.Add(time.Hour)}
+	token := signer.Sign(claims)
+	encoded, signature, _ := strings.Cut(token, ".")
+
+	// payload diganti ke chat lain dengan tanda tangan lama
+	forged := base64.RawURLEncoding.EncodeToString([]byte("chat-2:share-1:" + strings.Split(mustDecode(t, encoded), ":")[2]))
+
+	tests := []struct {
+		name    string
+		signer  *Signer
+		token   string
+		now     time.Time
+		wantErr error
+	}{
+		{name: "valid token", signer: signer, token: token, now: now},
+		{name: "tampered signature", signer: signer, token: encoded + "." + flipFirst(signature), now: now, wantErr: ErrInvalidToken},
+		{name: "tampered payload", signer: signer, token: forged + "." + signature, now: now, wantErr: ErrInvalidToken},
+		{name: "missing signature", signer: signer, token: encoded, now: now, wantErr: ErrInvalidToken},
+		{name: "signature is not base64", signer: signer, token: encoded + ".!!!", now: now, wantErr: ErrInvalidToken},
+		{name: "expired token", signer: signer, token: token, now: claims.ExpiresAt, wantErr: ErrExpiredToken},
+		{name: "wrong key", signer: other, token: token, now: now, wantErr: ErrInvalidToken},
+		{name: "share token on the audio signer", signer: audio, token: token, now: now, wantErr: ErrInvalidToken},
+		{name: "audio token on the share signer", signer: signer, token: audio.Sign(claims), now: now, wantErr: ErrInvalidToken},
+		{name: "audio token on the audio signer", signer: audio, token: audio.Sign(claims), now: now},
+		{name: "audio token on a signer derived for another purpose", signer: signer.Derive("export"), token: audio.Sign(claims), now: now, wantErr: ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.signer.Verify(tt.token, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.ChatID != claims.ChatID || got.ShareID != claims.ShareID || !got.ExpiresAt.Equal(claims.ExpiresAt) {
+				t.Errorf("Verify() = %+v, want %+v", got, claims)
+			}
+		})
+	}
+}
+
+// mustDecode digunakan untuk membuka payload token dalam base64 URL
+func mustDecode(t *testing.T, encoded string) string {
+	t.Helper()
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+
+	return string(payload)
+}
+
+// flipFirst digunakan untuk mengganti karakter pertama tanda tangan agar tanda tangan tidak cocok lagi,
+// karakter terakhir tidak dipakai karena sebagian bitnya hanya padding base64
+func flipFirst(signature string) string {
+	replacement := "A"
+	if signature[0] == 'A' {
+		replacement = "B"
+	}
+
+	return replacement + signature[1:]
+}
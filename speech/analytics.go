@@ -0,0 +1,221 @@
+package speech
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+)
+
+// LONG_PAUSE adalah jeda antar kata yang dihitung sebagai jeda panjang
+const LONG_PAUSE = 2 * time.Second
+
+// fillers adalah kata pengisi yang dihitung di jawaban user, dalam bahasa Inggris dan Indonesia
+var fillers = []string{"um", "uh", "like", "jadi", "eh"}
+
+// Metrics berisi analisis cara user berbicara di satu jawaban
+type Metrics struct {
+	Words int
+	// Duration adalah panjang audio jawaban
+	Duration time.Duration
+	// SpeakingTime adalah waktu dari kata pertama sampai kata terakhir
+	SpeakingTime   time.Duration
+	WordsPerMinute float64
+
+	FillerCount int
+	Fillers     map[string]int `bson:",omitempty"`
+
+	LongPauses   int
+	LongestPause time.Duration
+}
+
+// Summary berisi gabungan analisis bicara semua jawaban user
+type Summary struct {
+	Answers        int
+	Words          int
+	SpeakingTime   time.Duration
+	WordsPerMinute float64
+
+	FillerCount int
+	// FillersPerMinute adalah jumlah kata pengisi per menit bicara
+	FillersPerMinute float64
+	Fillers          map[string]int
+
+	LongPauses   int
+	LongestPause time.Duration
+	// AverageAnswer adalah rata-rata panjang audio jawaban
+	AverageAnswer time.Duration
+}
+
+// Analyze digunakan untuk menghitung analisis bicara dari hasil transkripsi dengan waktu kata dan segmen,
+// jika waktu kata tidak tersedia, jumlah kata dihitung dari teks dan jeda dari segmen
+func Analyze(transcript ai.TranscriptResponse) Metrics {
+	var words []string
+	for _, word := range transcript.Words {
+		words = append(words, word.Word)
+	}
+	if len(words) == 0 {
+		words = strings.Fields(transcript.Text)
+	}
+
+	metrics := Metrics{
+		Words:    len(words),
+		Duration: seconds(transcript.Duration),
+	}
+
+	// waktu bicara dan jeda diambil dari kata, atau dari segmen jika waktu kata tidak ada
+	var spans [][2]float64
+	for _, word := range transcript.Words {
+		spans = append(spans, [2]float64{word.Start, word.End})
+	}
+	if len(spans) == 0 {
+		for _, segment := range transcript.Segments {
+			spans = append(spans, [2]float64{segment.Start, segment.End})
+		}
+	}
+
+	if len(spans) > 0 {
+		metrics.SpeakingTime = seconds(spans[len(spans)-1][1] - spans[0][0])
+	} else {
+		metrics.SpeakingTime = metrics.Duration
+	}
+	if metrics.Duration == 0 {
+		metrics.Duration = metrics.SpeakingTime
+	}
+
+	for i := 1; i < len(spans); i++ {
+		pause := seconds(spans[i][0] - spans[i-1][1])
+		if pause >= LONG_PAUSE {
+			metrics.LongPauses++
+		}
+		if pause > metrics.LongestPause {
+			metrics.LongestPause = pause
+		}
+	}
+
+	if metrics.SpeakingTime > 0 {
+		metrics.WordsPerMinute = round(float64(metrics.Words) / metrics.SpeakingTime.Minutes())
+	}
+
+	for _, word := range words {
+		filler, ok := fillerOf(word)
+		if !ok {
+			continue
+		}
+
+		if metrics.Fillers == nil {
+			metrics.Fillers = make(map[string]int)
+		}
+		metrics.Fillers[filler]++
+		metrics.FillerCount++
+	}
+
+	return metrics
+}
+
+// Summarize digunakan untuk menggabungkan analisis bicara semua jawaban
+func Summarize(metrics []Metrics) Summary {
+	var summary Summary
+	var duration time.Duration
+	for _, m := range metrics {
+		summary.Answers++
+		summary.Words += m.Words
+		summary.SpeakingTime += m.SpeakingTime
+		summary.FillerCount += m.FillerCount
+		summary.LongPauses += m.LongPauses
+		duration += m.Duration
+
+		if m.LongestPause > summary.LongestPause {
+			summary.LongestPause = m.LongestPause
+		}
+
+		for filler, count := range m.Fillers {
+			if summary.Fillers == nil {
+				summary.Fillers = make(map[string]int)
+			}
+			summary.Fillers[filler] += count
+		}
+	}
+
+	if summary.SpeakingTime > 0 {
+		summary.WordsPerMinute = round(float64(summary.Words) / summary.SpeakingTime.Minutes())
+		summary.FillersPerMinute = round(float64(summary.FillerCount) / summary.SpeakingTime.Minutes())
+	}
+	if summary.Answers > 0 {
+		summary.AverageAnswer = (duration / time.Duration(summary.Answers)).Round(time.Second)
+	}
+
+	return summary
+}
+
+// Feedback digunakan untuk menulis ringkasan analisis bicara yang bisa dibaca AI
+func (s Summary) Feedback() string {
+	if s.Answers == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Speech analytics of the interviewee's %d spoken answers:\n", s.Answers)
+	fmt.Fprintf(&b, "- Speaking rate: %.0f words per minute\n", s.WordsPerMinute)
+	fmt.Fprintf(&b, "- Average answer length: %s (%d words in total)\n", s.AverageAnswer, s.Words)
+
+	fillers := make([]string, 0, len(s.Fillers))
+	for filler := range s.Fillers {
+		fillers = append(fillers, filler)
+	}
+	sort.Strings(fillers)
+	for i, filler := range fillers {
+		fillers[i] = fmt.Sprintf("%q %d", filler, s.Fillers[filler])
+	}
+	fmt.Fprintf(&b, "- Filler words: %d (%.1f per minute)", s.FillerCount, s.FillersPerMinute)
+	if len(fillers) > 0 {
+		fmt.Fprintf(&b, ": %s", strings.Join(fillers, ", "))
+	}
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "- Pauses of %s or longer: %d, the longest was %s\n", LONG_PAUSE, s.LongPauses, s.LongestPause.Round(100*time.Millisecond))
+
+	return b.String()
+}
+
+// fillerOf digunakan untuk mengecek apakah kata adalah kata pengisi,
+// huruf yang berulang seperti "ummm" atau "ehh" dianggap sama dengan "um" dan "eh"
+func fillerOf(word string) (string, bool) {
+	word = strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}))
+
+	var collapsed []rune
+	for _, r := range word {
+		if len(collapsed) > 0 && collapsed[len(collapsed)-1] == r {
+			continue
+		}
+		collapsed = append(collapsed, r)
+	}
+
+	for _, filler := range fillers {
+		if word == filler || string(collapsed) == filler {
+			return filler, true
+		}
+	}
+
+	return "", false
+}
+
+// seconds digunakan untuk mengubah detik dari Whisper menjadi time.Duration
+func seconds(value float64) time.Duration {
+	if value <= 0 {
+		return 0
+	}
+
+	return time.Duration(value * float64(time.Second))
+}
+
+// round digunakan untuk membulatkan nilai ke satu angka di belakang koma
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
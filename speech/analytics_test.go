@@ -0,0 +1,185 @@
+package speech
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name       string
+		transcript ai.TranscriptResponse
+		want       Metrics
+	}{
+		{
+			name: "word timestamps",
+			transcript: ai.TranscriptResponse{
+				Text:     "Um, I would use like channels.",
+				Duration: 10,
+				Words: []ai.TranscriptWord{
+					{Word: "Um", Start: 0, End: 0.5},
+					{Word: "I", Start: 0.5, End: 1},
+					{Word: "would", Start: 1, End: 1.5},
+					{Word: "use", Start: 4, End: 4.5},
+					{Word: "like", Start: 4.5, End: 5},
+					{Word: "channels", Start: 5, End: 6},
+				},
+			},
+			want: Metrics{
+				Words:          6,
+				Duration:       10 * time.Second,
+				SpeakingTime:   6 * time.Second,
+				WordsPerMinute: 60,
+				FillerCount:    2,
+				Fillers:        map[string]int{"um": 1, "like": 1},
+				LongPauses:     1,
+				LongestPause:   2500 * time.Millisecond,
+			},
+		},
+		{
+			name: "segment timestamps without word timestamps",
+			transcript: ai.TranscriptResponse{
+				Text: "Ummm jadi saya pakai channel",
+				Segments: []ai.TranscriptSegment{
+					{ID: 0, Start: 0, End: 3, Text: "Ummm jadi saya"},
+					{ID: 1, Start: 5.5, End: 9, Text: "pakai channel"},
+				},
+			},
+			want: Metrics{
+				Words:          5,
+				Duration:       9 * time.Second,
+				SpeakingTime:   9 * time.Second,
+				WordsPerMinute: 33.3,
+				FillerCount:    2,
+				Fillers:        map[string]int{"um": 1, "jadi": 1},
+				LongPauses:     1,
+				LongestPause:   2500 * time.Millisecond,
+			},
+		},
+		{
+			name:       "text without timestamps",
+			transcript: ai.TranscriptResponse{Text: "uh okay", Duration: 30},
+			want: Metrics{
+				Words:          2,
+				Duration:       30 * time.Second,
+				SpeakingTime:   30 * time.Second,
+				WordsPerMinute: 4,
+				FillerCount:    1,
+				Fillers:        map[string]int{"uh": 1},
+			},
+		},
+		{
+			name:       "short pauses are not counted",
+			transcript: ai.TranscriptResponse{Words: []ai.TranscriptWord{{Word: "yes", Start: 0, End: 1}, {Word: "sure", Start: 2, End: 3}}},
+			want: Metrics{
+				Words:          2,
+				Duration:       3 * time.Second,
+				SpeakingTime:   3 * time.Second,
+				WordsPerMinute: 40,
+				LongestPause:   time.Second,
+			},
+		},
+		{
+			name: "empty transcript",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Analyze(tt.transcript); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Analyze() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFillerOf(t *testing.T) {
+	tests := []struct {
+		word   string
+		want   string
+		wantOk bool
+	}{
+		{word: "um", want: "um", wantOk: true},
+		{word: "Um,", want: "um", wantOk: true},
+		{word: "UHHH...", want: "uh", wantOk: true},
+		{word: "ehh", want: "eh", wantOk: true},
+		{word: "Jadi.", want: "jadi", wantOk: true},
+		{word: "like", want: "like", wantOk: true},
+		{word: "likely"},
+		{word: "mum"},
+		{word: "..."},
+		{word: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			got, ok := fillerOf(tt.word)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("fillerOf(%q) = %q, %v, want %q, %v", tt.word, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics []Metrics
+		want    Summary
+	}{
+		{
+			name: "answers are combined",
+			metrics: []Metrics{
+				{
+					Words:        60,
+					Duration:     40 * time.Second,
+					SpeakingTime: 30 * time.Second,
+					FillerCount:  2,
+					Fillers:      map[string]int{"um": 2},
+					LongPauses:   1,
+					LongestPause: 2500 * time.Millisecond,
+				},
+				{
+					Words:        30,
+					Duration:     21 * time.Second,
+					SpeakingTime: 30 * time.Second,
+					FillerCount:  2,
+					Fillers:      map[string]int{"um": 1, "like": 1},
+					LongestPause: time.Second,
+				},
+			},
+			want: Summary{
+				Answers:          2,
+				Words:            90,
+				SpeakingTime:     time.Minute,
+				WordsPerMinute:   90,
+				FillerCount:      4,
+				FillersPerMinute: 4,
+				Fillers:          map[string]int{"um": 3, "like": 1},
+				LongPauses:       1,
+				LongestPause:     2500 * time.Millisecond,
+				// rata-rata 30.5 detik dibulatkan ke detik terdekat
+				AverageAnswer: 31 * time.Second,
+			},
+		},
+		{
+			name:    "answer without speaking time",
+			metrics: []Metrics{{Words: 3}},
+			want:    Summary{Answers: 1, Words: 3},
+		},
+		{
+			name: "no answers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Summarize(tt.metrics); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Summarize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,18 @@ const baseUrl = 'http://localhost:8080';
 
 const chatWindow = document.getElementById('chat-window');
 const recordButton = document.getElementById('record-btn');
+const languageSelect = document.getElementById('language-select');
+const recordCheck = document.getElementById('record-check');
+const codePanel = document.getElementById('code-panel');
+const codeProblem = document.getElementById('code-problem');
+const codeEditor = document.getElementById('code-editor');
+const codeButton = document.getElementById('code-btn');
+const templateSelect = document.getElementById('template-select');
+const designPanel = document.getElementById('design-panel');
+const designButton = document.getElementById('design-btn');
+const exportFormat = document.getElementById('export-format');
+const exportButton = document.getElementById('export-btn');
+const shareButton = document.getElementById('share-btn');
 recordButton.state = {
   initial: true,
   recording: false,
@@ -9,6 +21,7 @@ recordButton.state = {
 
 let mediaRecorder;
 let audioChunks = [];
+let answerCount = 0;
 
 function setAuthorization(userId, userSecret) {
   localStorage.setItem('userId', userId);
@@ -42,7 +55,11 @@ recordButton.onclick = () => {
 
 async function initChat() {
   try {
-    const response = await fetch(`${baseUrl}/chat/start`)
+    // kirim bahasa yang dipilih
+    const language = encodeURIComponent(languageSelect.value);
+    const template = encodeURIComponent(templateSelect.value);
+    const record = recordCheck.checked;
+    const response = await fetch(`${baseUrl}/chat/start?language=${language}&template=${template}&record=${record}`)
     const data = await response.json();
 
     // simpan userId dan userSecret
@@ -52,11 +69,16 @@ async function initChat() {
 
     // tampilkan pesan awal
     const initialMessage = data.data.text;
-    appendMessage(initialMessage, 'assistant');
+    appendMessage(initialMessage, 'assistant', false, data.data.speaker);
 
     // putar audio awal
-    const initialAudio = data.data.audio;
-    decodeAndPlayAudio(initialAudio);
+    const initialAudioUrl = data.data.audio_url;
+    playAudioUrl(initialAudioUrl);
+
+    // bahasa dan pilihan rekaman tidak bisa diubah setelah interview dimulai
+    languageSelect.disabled = true;
+    templateSelect.disabled = true;
+    recordCheck.disabled = true;
 
     // atur button sudah diklik
     buttonIdle();
@@ -118,15 +140,27 @@ async function sendAudio(audioBlob) {
     const data = await response.json()
     // tampilkan pesan hasil transkripsi
     const userMessage = data.data.prompt.text;
-    appendMessage(userMessage, 'user');
+    appendMessage(userMessage, 'user', true);
 
     // tampikan pesan jawaban
     const replyMessage = data.data.answer.text;
-    appendMessage(replyMessage, 'assistant')
+    appendMessage(replyMessage, 'assistant', false, data.data.answer.speaker)
+
+    // tampilkan editor jika interviewer memberikan soal coding
+    showProblem(data.data.problem);
+
+    // tampilkan papan desain selama tahap system design
+    designPanel.hidden = data.data.stage !== 'system-design';
 
     // putar audio jawaban
-    const replyAudio = data.data.answer.audio;
-    decodeAndPlayAudio(replyAudio);
+    const replyAudioUrl = data.data.answer.audio_url;
+    playAudioUrl(replyAudioUrl);
+
+    // interview selesai setelah tahap terakhir
+    if (data.data.finished) {
+      buttonFinished();
+      return;
+    }
 
     // atur button menjadi menunggu merekam
     buttonIdle();
@@ -140,6 +174,139 @@ async function sendAudio(audioBlob) {
 
 }
 
+codeButton.onclick = () => {
+  submitCode();
+}
+
+function showProblem(problem) {
+  if (!problem) {
+    return;
+  }
+
+  // tampilkan soal dan kode awal di editor
+  codeProblem.textContent = problem.text;
+  codeEditor.value = problem.starter_code || '';
+  codePanel.hidden = false;
+}
+
+async function submitCode() {
+  // atur button agar tidak bisa diklik selama kode dijalankan
+  codeButton.disabled = true;
+  recordButton.disabled = true;
+
+  try {
+    // kirim kode ke server
+    const response = await fetch(`${baseUrl}/chat/code`, {
+      method: 'POST',
+      body: JSON.stringify({ code: codeEditor.value }),
+      headers: {
+        'Authorization': `Basic ${getAuthorization()}`,
+        'Content-Type': 'application/json'
+      }
+    })
+    const data = await response.json()
+    if (!response.ok) {
+      alert(data.message);
+      return;
+    }
+
+    // tampilkan hasil test dan jawaban interviewer
+    const submission = data.data.submission;
+    appendMessage(`Code submitted: ${submission.status}, ${submission.passed} of ${submission.total} tests passed.`, 'user');
+    appendMessage(data.data.answer.text, 'assistant', false, data.data.answer.speaker);
+    showProblem(data.data.problem);
+
+    playAudioUrl(data.data.answer.audio_url);
+
+    if (data.data.finished) {
+      codePanel.hidden = true;
+      buttonFinished();
+    }
+  } catch (error) {
+    console.error('Error:', error);
+    alert('Error submitting code, please try again.');
+  } finally {
+    codeButton.disabled = false;
+    if (!recordButton.state.finished) {
+      recordButton.disabled = false;
+    }
+  }
+}
+
+designButton.onclick = () => {
+  submitDesign();
+}
+
+function parseLines(id, pattern, fields) {
+  // ubah setiap baris textarea menjadi objek sesuai pola
+  return document.getElementById(id).value
+    .split('\n')
+    .map((line) => line.trim())
+    .filter((line) => line !== '')
+    .map((line) => {
+      const match = line.match(pattern) || [];
+      const item = {};
+      fields.forEach((field, i) => {
+        item[field] = (match[i + 1] || '').trim();
+      });
+      return item;
+    });
+}
+
+async function submitDesign() {
+  const design = {
+    components: parseLines('design-components', /^([^:]+):?(.*)$/, ['name', 'responsibility']),
+    data_stores: parseLines('design-stores', /^([^(:]+)(?:\(([^)]*)\))?:?(.*)$/, ['name', 'kind', 'purpose']),
+    apis: parseLines('design-apis', /^(?:([A-Z]+)\s+)?([^\s:]+):?(.*)$/, ['method', 'path', 'description']),
+    diagram: {
+      format: document.getElementById('design-format').value,
+      source: document.getElementById('design-diagram').value,
+    },
+    notes: document.getElementById('design-notes').value,
+  };
+
+  // atur button agar tidak bisa diklik selama desain diproses
+  designButton.disabled = true;
+  recordButton.disabled = true;
+
+  try {
+    // kirim desain ke server
+    const response = await fetch(`${baseUrl}/chat/design`, {
+      method: 'POST',
+      body: JSON.stringify(design),
+      headers: {
+        'Authorization': `Basic ${getAuthorization()}`,
+        'Content-Type': 'application/json'
+      }
+    })
+    const data = await response.json()
+    if (!response.ok) {
+      alert(data.message);
+      return;
+    }
+
+    // tampilkan jawaban interviewer
+    const submitted = data.data.design;
+    appendMessage(`Design submitted: ${submitted.components.length} components, ${(submitted.edges || []).length} connections.`, 'user');
+    appendMessage(data.data.answer.text, 'assistant', false, data.data.answer.speaker);
+    designPanel.hidden = data.data.stage !== 'system-design';
+
+    playAudioUrl(data.data.answer.audio_url);
+
+    if (data.data.finished) {
+      buttonFinished();
+    }
+  } catch (error) {
+    console.error('Error:', error);
+    alert('Error submitting design, please try again.');
+  } finally {
+    designButton.disabled = false;
+    if (!recordButton.state.finished) {
+      recordButton.disabled = false;
+    }
+  }
+}
+
 function stopRecording() {
   // hentikan rekaman
   mediaRecorder.stop();
@@ -148,7 +315,7 @@ function stopRecording() {
   buttonProcessing();
 }
 
-function appendMessage(message, type) {
+function appendMessage(message, type, spoken, speaker) {
   // buat div
   const messageDiv = document.createElement('div');
 
@@ -156,6 +323,22 @@ function appendMessage(message, type) {
   messageDiv.className = `message ${type}`;
   messageDiv.textContent = message;
 
+  // pada mode panel tampilkan interviewer yang berbicara
+  if (speaker) {
+    const speakerDiv = document.createElement('div');
+    speakerDiv.className = 'speaker';
+    speakerDiv.textContent = `${speaker.name} · ${speaker.role}`;
+    messageDiv.prepend(speakerDiv);
+  }
+
+  // setiap pesan user adalah satu jawaban, jawaban lisan bisa dibandingkan dengan contoh jawaban
+  if (type === 'user') {
+    answerCount++;
+    if (spoken) {
+      messageDiv.dataset.turn = answerCount;
+    }
+  }
+
   // tambahkan ke chat window
   chatWindow.appendChild(messageDiv);
 
@@ -163,27 +346,10 @@ function appendMessage(message, type) {
   chatWindow.scrollTop = chatWindow.scrollHeight;
 }
 
-function decodeAndPlayAudio(encodedAudio) {
-  // decode base64
-  const audioData = atob(encodedAudio);
-
-  // ubah menjadi array buffer
-  const audioBuffer = new ArrayBuffer(audioData.length);
-  const audioView = new Uint8Array(audioBuffer);
-
-  // isi array buffer dengan data audio
-  for (let i = 0; i < audioData.length; i++) {
-    audioView[i] = audioData.charCodeAt(i);
-  }
-
-  // buat blob dari array buffer
-  const audioBlob = new Blob([audioBuffer], { type: 'audio/wav' });
-
-  // buat url dari blob
-  const audioUrl = URL.createObjectURL(audioBlob);
-
-  // putar audio
-  const audio = new Audio(audioUrl);
+function playAudioUrl(audioUrl) {
+  // putar audio langsung dari server, URL audio jawaban sudah bertanda tangan
+  // sehingga browser bisa melakukan streaming tanpa header Authorization
+  const audio = new Audio(`${baseUrl}${audioUrl}`);
   audio.play();
 }
 
@@ -207,6 +373,136 @@ function buttonIdle() {
   recordButton.innerHTML = '<i class="bi bi-record-circle"></i> Record Answer';
 }
 
+function buttonFinished() {
+  // interview sudah selesai sehingga button tidak bisa diklik lagi
+  recordButton.state.finished = true;
+  recordButton.disabled = true;
+  recordButton.innerHTML = '<i class="bi bi-check-circle"></i> Interview Finished';
+
+  showIdealButtons();
+
+  // transcript bisa diunduh setelah interview selesai
+  exportFormat.hidden = false;
+  exportButton.hidden = false;
+  shareButton.hidden = false;
+}
+
+function showIdealButtons() {
+  // tambahkan tombol contoh jawaban di setiap jawaban lisan
+  chatWindow.querySelectorAll('.message.user[data-turn]').forEach((messageDiv) => {
+    const button = document.createElement('button');
+    button.className = 'btn btn-sm btn-outline-light ideal-btn';
+    button.innerHTML = '<i class="bi bi-lightbulb"></i> Ideal answer';
+    button.onclick = () => showIdealAnswer(messageDiv, button);
+    messageDiv.appendChild(button);
+  });
+}
+
+async function showIdealAnswer(messageDiv, button) {
+  button.disabled = true;
+
+  try {
+    const response = await fetch(`${baseUrl}/chat/answers/${messageDiv.dataset.turn}/ideal`, {
+      headers: {
+        'Authorization': `Basic ${getAuthorization()}`
+      }
+    });
+    const data = await response.json();
+    if (!response.ok) {
+      alert(data.message);
+      button.disabled = false;
+      return;
+    }
+
+    // tampilkan contoh jawaban di bawah jawaban user, bagian yang tidak dibahas user ditandai
+    const idealDiv = document.createElement('div');
+    idealDiv.className = 'message ideal';
+    data.data.segments.forEach((segment) => {
+      const span = document.createElement(segment.missing ? 'mark' : 'span');
+      span.textContent = `${segment.text} `;
+      if (segment.key_point) {
+        span.title = segment.key_point;
+      }
+      idealDiv.appendChild(span);
+    });
+
+    if (data.data.missing.length > 0) {
+      const missingList = document.createElement('ul');
+      missingList.className = 'ideal-missing';
+      data.data.missing.forEach((point) => {
+        const item = document.createElement('li');
+        item.textContent = point;
+        missingList.appendChild(item);
+      });
+      idealDiv.appendChild(missingList);
+    }
+
+    messageDiv.after(idealDiv);
+    button.remove();
+  } catch (error) {
+    console.error('Error:', error);
+    alert('Error getting ideal answer, please try again.');
+    button.disabled = false;
+  }
+}
+
+exportButton.onclick = async () => {
+  exportButton.disabled = true;
+
+  try {
+    const response = await fetch(`${baseUrl}/chat/export?format=${exportFormat.value}`, {
+      headers: {
+        'Authorization': `Basic ${getAuthorization()}`
+      }
+    });
+    if (!response.ok) {
+      const data = await response.json();
+      alert(data.message);
+      return;
+    }
+
+    // unduh file dengan nama dari server
+    const blob = await response.blob();
+    const filename = (response.headers.get('Content-Disposition') || '').match(/filename="(.+)"/);
+    const link = document.createElement('a');
+    link.href = URL.createObjectURL(blob);
+    link.download = filename ? filename[1] : `interview.${exportFormat.value}`;
+    link.click();
+    URL.revokeObjectURL(link.href);
+  } catch (error) {
+    console.error('Error:', error);
+    alert('Error downloading transcript, please try again.');
+  } finally {
+    exportButton.disabled = false;
+  }
+}
+
+shareButton.onclick = async () => {
+  shareButton.disabled = true;
+
+  try {
+    const response = await fetch(`${baseUrl}/chat/shares`, {
+      method: 'POST',
+      headers: {
+        'Authorization': `Basic ${getAuthorization()}`
+      }
+    });
+    const data = await response.json();
+    if (!response.ok) {
+      alert(data.message);
+      return;
+    }
+
+    // link membuka transcript dalam format HTML tanpa perlu login
+    prompt('Read-only link, valid until ' + new Date(data.data.expires_at).toLocaleString(), `${baseUrl}${data.data.url}/export?format=html`);
+  } catch (error) {
+    console.error('Error:', error);
+    alert('Error creating share link, please try again.');
+  } finally {
+    shareButton.disabled = false;
+  }
+}
+
 function buttonProcessing() {
   // atur button agar tidak bisa diklik & beri loading spinner
   recordButton.disabled = true;
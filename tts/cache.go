@@ -0,0 +1,151 @@
+package tts
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// DefaultCacheSize adalah jumlah audio maksimal yang disimpan di memori
+const DefaultCacheSize = 128
+
+// keyPattern digunakan untuk memvalidasi key sebelum dipakai sebagai nama file
+var keyPattern = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+// Cache menyimpan audio TTS berdasarkan konten di memori (LRU) dan opsional di disk
+type Cache struct {
+	mu       sync.Mutex
+	capacity int
+	dir      string
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+type cacheItem struct {
+	key   string
+	audio []byte
+}
+
+// NewCache digunakan untuk membuat cache TTS,
+// audio hanya disimpan di memori jika dir kosong
+func NewCache(capacity int, dir string) (*Cache, error) {
+	if capacity <= 0 {
+		capacity = DefaultCacheSize
+	}
+
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Cache{
+		capacity: capacity,
+		dir:      dir,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}, nil
+}
+
+// Key digunakan untuk membuat key cache dari provider, suara, dan teks
+func Key(provider, voice, text string) string {
+	sum := sha256.Sum256([]byte(provider + "\x00" + voice + "\x00" + text))
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidKey digunakan untuk mengecek format key cache
+func ValidKey(key string) bool {
+	return keyPattern.MatchString(key)
+}
+
+// Get digunakan untuk mengambil audio dari memori, lalu dari disk
+func (c *Cache) Get(key string) ([]byte, bool) {
+	if !ValidKey(key) {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	if elem, ok := c.items[key]; ok {
+		c.order.MoveToFront(elem)
+		audio := elem.Value.(*cacheItem).audio
+		c.mu.Unlock()
+
+		return audio, true
+	}
+	c.mu.Unlock()
+
+	if c.dir == "" {
+		return nil, false
+	}
+
+	audio, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	// simpan ke memori agar pembacaan berikutnya tidak ke disk
+	c.store(key, audio)
+
+	return audio, true
+}
+
+// Put digunakan untuk menyimpan audio ke memori dan disk
+func (c *Cache) Put(key string, audio []byte) error {
+	if !ValidKey(key) {
+		return errors.New("invalid cache key")
+	}
+
+	c.store(key, audio)
+
+	if c.dir == "" {
+		return nil
+	}
+
+	// tulis ke file sementara agar pembaca lain tidak mendapat file setengah jadi
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(audio); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// store digunakan untuk menyimpan audio ke memori dan membuang yang paling lama tidak dipakai
+func (c *Cache) store(key string, audio []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*cacheItem).audio = audio
+		c.order.MoveToFront(elem)
+
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&cacheItem{key: key, audio: audio})
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheItem).key)
+	}
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+".mp3")
+}
@@ -0,0 +1,181 @@
+package tts
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	// key tersimpan di disk dan URL, perubahan cara membuat key membuat cache lama tidak terpakai
+	if got, want := Key("openai", "nova", "Hello"), "be0e6538008e79847227e5556c18b311e9c45585d792ae97ba4a3c055ba15af6"; got != want {
+		t.Errorf("Key() = %s, want %s", got, want)
+	}
+
+	tests := []struct {
+		name  string
+		other string
+	}{
+		{name: "different provider", other: Key("elevenlabs", "nova", "Hello")},
+		{name: "different voice", other: Key("openai", "alloy", "Hello")},
+		{name: "different text", other: Key("openai", "nova", "Hello!")},
+		{name: "same characters across fields", other: Key("openai", "novaH", "ello")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.other == Key("openai", "nova", "Hello") {
+				t.Errorf("Key() = %s for different input", tt.other)
+			}
+			if !ValidKey(tt.other) {
+				t.Errorf("ValidKey(%s) = false, want true", tt.other)
+			}
+		})
+	}
+}
+
+func TestValidKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want bool
+	}{
+		{name: "sha256 hex", key: Key("openai", "nova", "Hello"), want: true},
+		{name: "uppercase hex", key: "BE0E6538008E79847227E5556C18B311E9C45585D792AE97BA4A3C055BA15AF6"},
+		{name: "too short", key: "be0e6538"},
+		{name: "path traversal", key: "../../../../etc/passwd"},
+		{name: "empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidKey(tt.key); got != tt.want {
+				t.Errorf("ValidKey(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache, err := NewCache(2, "")
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+
+	first, second, third := Key("p", "v", "first"), Key("p", "v", "second"), Key("p", "v", "third")
+	for _, key := range []string{first, second} {
+		if err := cache.Put(key, []byte(key)); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+	}
+
+	// first dipakai lagi sehingga second menjadi yang paling lama tidak dipakai
+	if _, ok := cache.Get(first); !ok {
+		t.Fatal("Get(first) missed before eviction")
+	}
+	if err := cache.Put(third, []byte(third)); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		want bool
+	}{
+		{name: "recently used", key: first, want: true},
+		{name: "least recently used", key: second},
+		{name: "newest", key: third, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audio, ok := cache.Get(tt.key)
+			if ok != tt.want {
+				t.Fatalf("Get() ok = %v, want %v", ok, tt.want)
+			}
+			if ok && string(audio) != tt.key {
+				t.Errorf("Get() = %q, want %q", audio, tt.key)
+			}
+		})
+	}
+}
+
+func TestCachePutReplacesAudio(t *testing.T) {
+	cache, err := NewCache(1, "")
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+
+	key := Key("p", "v", "text")
+	cache.Put(key, []byte("old"))
+	cache.Put(key, []byte("new"))
+
+	if audio, ok := cache.Get(key); !ok || string(audio) != "new" {
+		t.Errorf("Get() = %q, %v, want %q, true", audio, ok, "new")
+	}
+}
+
+func TestCacheDisk(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "tts")
+	key := Key("openai", "nova", "Hello")
+	audio := []byte("mp3 audio")
+
+	cache, err := NewCache(1, dir)
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+	if err := cache.Put(key, audio); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	// hanya file audio yang tersisa, file sementara sudah dihapus
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read cache dir: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != key+".mp3" {
+		t.Errorf("cache dir = %v, want only %s.mp3", entries, key)
+	}
+
+	// cache baru dengan direktori yang sama membaca audio dari disk, seperti setelah server restart
+	restarted, err := NewCache(1, dir)
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+	got, ok := restarted.Get(key)
+	if !ok || !bytes.Equal(got, audio) {
+		t.Fatalf("Get() after restart = %q, %v, want %q, true", got, ok, audio)
+	}
+
+	// audio yang dibuang dari memori tetap bisa diambil dari disk
+	other := Key("openai", "nova", "Bye")
+	if err := restarted.Put(other, []byte("other")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if got, ok := restarted.Get(key); !ok || !bytes.Equal(got, audio) {
+		t.Errorf("Get() after eviction = %q, %v, want %q, true", got, ok, audio)
+	}
+
+	if _, ok := restarted.Get(Key("openai", "nova", "missing")); ok {
+		t.Error("Get() of a missing key hit the cache")
+	}
+}
+
+func TestCacheRejectsInvalidKey(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewCache(1, dir)
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+
+	if err := cache.Put("../escape", []byte("audio")); err == nil {
+		t.Error("Put() with an invalid key error = nil, want error")
+	}
+	if _, ok := cache.Get("../escape"); ok {
+		t.Error("Get() with an invalid key hit the cache")
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(dir), "escape.mp3")); !os.IsNotExist(err) {
+		t.Errorf("invalid key was written outside the cache dir: %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package tts
+
+import (
+	"io"
+
+	"github.com/fastcampus-backend-golang/ai-interview/ai"
+)
+
+// Synthesizer membuat audio TTS melalui cache
+type Synthesizer struct {
+	client   ai.Client
+	provider string
+	voice    string
+	cache    *Cache
+}
+
+// NewSynthesizer digunakan untuk membuat synthesizer dengan cache
+func NewSynthesizer(client ai.Client, provider, voice string, cache *Cache) *Synthesizer {
+	return &Synthesizer{
+		client:   client,
+		provider: provider,
+		voice:    voice,
+		cache:    cache,
+	}
+}
+
+// Synthesize digunakan untuk membuat audio dari teks dengan suara tertentu, voice kosong berarti suara default,
+// audio diambil dari cache jika teks yang sama sudah pernah dibuat. Cache tidak dienkripsi dan bisa diambil
+// tanpa login, jadi hanya teks yang sama untuk semua user seperti kalimat pembuka yang boleh memakai cache
+func (s *Synthesizer) Synthesize(text, voice string) (string, []byte, error) {
+	if voice == "" {
+		voice = s.voice
+	}
+	key := Key(s.provider, voice, text)
+
+	if audio, ok := s.cache.Get(key); ok {
+		return key, audio, nil
+	}
+
+	audio, err := s.Speak(text, voice)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := s.cache.Put(key, audio); err != nil {
+		return "", nil, err
+	}
+
+	return key, audio, nil
+}
+
+// Speak digunakan untuk membuat audio dari teks tanpa menyimpannya di cache,
+// dipakai untuk teks yang berisi data interview seperti balasan interviewer
+func (s *Synthesizer) Speak(text, voice string) ([]byte, error) {
+	if voice == "" {
+		voice = s.voice
+	}
+
+	speech, err := s.client.TextToSpeech(text, voice)
+	if err != nil {
+		return nil, err
+	}
+	defer speech.Close()
+
+	return io.ReadAll(speech)
+}
+
+// Get digunakan untuk mengambil audio dari cache berdasarkan key
+func (s *Synthesizer) Get(key string) ([]byte, bool) {
+	return s.cache.Get(key)
+}
+
+// Provider digunakan untuk mendapatkan identitas provider dan suara TTS, voice kosong berarti suara default
+func (s *Synthesizer) Provider(voice string) string {
+	if voice == "" {
+		voice = s.voice
+	}
+
+	return s.provider + "/" + voice
+}